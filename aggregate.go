@@ -0,0 +1,74 @@
+package sqlg
+
+import (
+	"fmt"
+
+	"github.com/wwwangxc/sqlg/internal"
+)
+
+// Count aggregate expression, can be used as column of select statement and
+// having condition
+//
+// EXP:
+//
+//	COUNT(${column})
+func Count(column string) RawExpr {
+	return aggregate("COUNT", column)
+}
+
+// CountDistinct aggregate expression
+//
+// EXP:
+//
+//	COUNT(DISTINCT ${column})
+func CountDistinct(column string) RawExpr {
+	return Raw(fmt.Sprintf("COUNT(DISTINCT %s)", quoteColumn(column)))
+}
+
+// Sum aggregate expression
+//
+// EXP:
+//
+//	SUM(${column})
+func Sum(column string) RawExpr {
+	return aggregate("SUM", column)
+}
+
+// Avg aggregate expression
+//
+// EXP:
+//
+//	AVG(${column})
+func Avg(column string) RawExpr {
+	return aggregate("AVG", column)
+}
+
+// Min aggregate expression
+//
+// EXP:
+//
+//	MIN(${column})
+func Min(column string) RawExpr {
+	return aggregate("MIN", column)
+}
+
+// Max aggregate expression
+//
+// EXP:
+//
+//	MAX(${column})
+func Max(column string) RawExpr {
+	return aggregate("MAX", column)
+}
+
+// aggregate return the aggregate expression as trusted expression
+func aggregate(function, column string) RawExpr {
+	return Raw(fmt.Sprintf("%s(%s)", function, quoteColumn(column)))
+}
+
+// quoteColumn return the column quoted with backtick in strict mode, which
+// will be requoted with the dialect of statement, the expression must be
+// created by Raw
+func quoteColumn(column string) string {
+	return internal.SafeName(strictDialect{MySQL}, column)
+}
@@ -0,0 +1,36 @@
+package sqlg
+
+import "testing"
+
+func TestAggregate(t *testing.T) {
+	ops := []Option{
+		WithAnd("status", EQ("paid")),
+		WithGroupBy("user_id"),
+		WithHaving(Count("*"), GT(5)),
+		WithHaving(Sum("amount"), GTE(100)),
+		WithOrHaving(CountDistinct("o.product_id"), EQ(1)),
+		WithOrderByDESC(Max("amount")),
+	}
+
+	g := NewGenerator("order", ops...)
+	gotSQL, gotParams := g.Select("user_id", Count("*"), Sum("amount"), Avg("amount"), Min("amount"), Max("amount"))
+
+	wantSQL := "SELECT `user_id`, COUNT(*), SUM(`amount`), AVG(`amount`), MIN(`amount`), MAX(`amount`) FROM `order` " +
+		"WHERE `status`=? GROUP BY `user_id` " +
+		"HAVING COUNT(*)>? AND SUM(`amount`)>=? OR COUNT(DISTINCT `o`.`product_id`)=? " +
+		"ORDER BY MAX(`amount`) DESC"
+	wantParams := []interface{}{"paid", 5, 100, 1}
+	assertSQL(t, gotSQL, wantSQL)
+	assertParams(t, gotParams, wantParams)
+
+	// requoted with the dialect
+	g = NewGenerator("order", append(ops, WithDialect(Postgres))...)
+	gotSQL, gotParams = g.Select("user_id", Count("*"), Sum("amount"), Avg("amount"), Min("amount"), Max("amount"))
+
+	wantSQL = `SELECT "user_id", COUNT(*), SUM("amount"), AVG("amount"), MIN("amount"), MAX("amount") FROM "order" ` +
+		`WHERE "status"=$1 GROUP BY "user_id" ` +
+		`HAVING COUNT(*)>$2 AND SUM("amount")>=$3 OR COUNT(DISTINCT "o"."product_id")=$4 ` +
+		`ORDER BY MAX("amount") DESC`
+	assertSQL(t, gotSQL, wantSQL)
+	assertParams(t, gotParams, wantParams)
+}
@@ -1,5 +1,7 @@
 package sqlg
 
+import "github.com/wwwangxc/sqlg/internal"
+
 // AssExpr assignment expression
 type AssExpr struct {
 	m map[string]interface{}
@@ -14,7 +16,14 @@ func NewAssExpr() *AssExpr {
 	}
 }
 
-// Put column and value into the assignment expression
+// Put column and value into the assignment expression, the value can be the
+// expression of Raw, Col, Incr, Decr, Func and Values, which is written
+// inline
+//
+// EXP:
+//
+//	${column}=?
+//	${column}=${expr}
 func (a *AssExpr) Put(column string, value interface{}) {
 	if a == nil {
 		return
@@ -31,6 +40,24 @@ func (a *AssExpr) Put(column string, value interface{}) {
 	a.m[column] = value
 }
 
+// validate the columns and the trusted expressions of values
+func (a *AssExpr) validate(d internal.Dialect) error {
+	var err error
+	a.each(func(column string, value interface{}) {
+		if err != nil {
+			return
+		}
+
+		if err = internal.ValidateName(d, column); err != nil {
+			return
+		}
+
+		err = internal.ValidateValues(value)
+	})
+
+	return err
+}
+
 func (a *AssExpr) exist(column string) bool {
 	if a.empty() {
 		return false
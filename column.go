@@ -0,0 +1,176 @@
+package sqlg
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/wwwangxc/sqlg/internal"
+)
+
+// Col reference the column, which can be used as value of expression,
+// assignment and record, the column is quoted with the dialect of statement
+//
+// EXP:
+//
+//	WithAnd("updated_at", GT(Col("created_at")))
+//	`updated_at`>`created_at`
+func Col(column string) RawExpr {
+	return Raw(quoteColumn(column))
+}
+
+// Add arithmetic expression, the operands can be column reference, trusted
+// expression or value which is bound as param
+//
+// EXP:
+//
+//	Add(Col("reserved"), 10)
+//	${x} + ${y}
+func Add(x, y interface{}) RawExpr {
+	return arithmetic("+", x, y)
+}
+
+// Sub arithmetic expression
+//
+// EXP:
+//
+//	${x} - ${y}
+func Sub(x, y interface{}) RawExpr {
+	return arithmetic("-", x, y)
+}
+
+// Mul arithmetic expression
+//
+// EXP:
+//
+//	${x} * ${y}
+func Mul(x, y interface{}) RawExpr {
+	return arithmetic("*", x, y)
+}
+
+// Div arithmetic expression
+//
+// EXP:
+//
+//	${x} / ${y}
+func Div(x, y interface{}) RawExpr {
+	return arithmetic("/", x, y)
+}
+
+// Mod arithmetic expression
+//
+// EXP:
+//
+//	${x} % ${y}
+func Mod(x, y interface{}) RawExpr {
+	return arithmetic("%", x, y)
+}
+
+// precedence of arithmetic expression, the operand is parenthesized when its
+// precedence is lower than the operator
+const (
+	precedenceUnknown = iota
+	precedenceAdditive
+	precedenceMultiplicative
+	precedencePrimary
+)
+
+// arithmetic return the trusted expression of binary operator, the operands
+// are parenthesized only when the precedence requires, the right operand of
+// same precedence is parenthesized to keep the order of evaluation
+func arithmetic(op string, x, y interface{}) RawExpr {
+	p := operatorPrecedence(op)
+	left, right := "?", "?"
+	if operandPrecedence(x) < p {
+		left = "(?)"
+	}
+
+	if operandPrecedence(y) <= p {
+		right = "(?)"
+	}
+
+	return Raw(fmt.Sprintf("%s %s %s", left, op, right), x, y)
+}
+
+func operatorPrecedence(op string) int {
+	switch op {
+	case "+", "-":
+		return precedenceAdditive
+	case "*", "/", "%":
+		return precedenceMultiplicative
+	default:
+		return precedenceUnknown
+	}
+}
+
+// operandPrecedence return the precedence of the operand, the value bound as
+// param is primary
+func operandPrecedence(v interface{}) int {
+	t, ok := v.(internal.Trusted)
+	if !ok {
+		return precedencePrimary
+	}
+
+	sql, _ := t.RawSQL()
+	return exprPrecedence(sql)
+}
+
+// exprPrecedence return the lowest precedence of the operators outside the
+// parentheses and quotes, the expression is unknown unless it's the operands
+// joined by the arithmetic operators with spaces
+func exprPrecedence(sql string) int {
+	var tokens []string
+	var operators []bool
+	var quote byte
+	var depth, start int
+	var operator bool
+	for i := 0; i <= len(sql); i++ {
+		if i == len(sql) || (quote == 0 && depth == 0 && sql[i] == ' ') {
+			tokens = append(tokens, sql[start:i])
+			operators = append(operators, operator)
+			start, operator = i+1, false
+			continue
+		}
+
+		c := sql[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'', c == '"', c == '`':
+			quote = c
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+		case depth == 0 && strings.IndexByte("+-*/%", c) >= 0:
+			operator = true
+		}
+	}
+
+	if len(tokens)%2 == 0 {
+		return precedenceUnknown
+	}
+
+	p := precedencePrimary
+	for i, v := range tokens {
+		if i%2 == 0 {
+			if v == "" || operators[i] {
+				return precedenceUnknown
+			}
+
+			continue
+		}
+
+		op := operatorPrecedence(v)
+		if op == precedenceUnknown {
+			return precedenceUnknown
+		}
+
+		if op < p {
+			p = op
+		}
+	}
+
+	return p
+}
@@ -0,0 +1,136 @@
+package sqlg
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestArithmetic(t *testing.T) {
+	tests := []struct {
+		name       string
+		expr       RawExpr
+		wantSQL    string
+		wantParams []interface{}
+	}{
+		{
+			name:    "column",
+			expr:    Col("o.amount"),
+			wantSQL: "`o`.`amount`",
+		},
+		{
+			name:       "add",
+			expr:       Add(Col("reserved"), 10),
+			wantSQL:    "`reserved` + ?",
+			wantParams: []interface{}{10},
+		},
+		{
+			name:    "columns",
+			expr:    Mul(Col("price"), Col("quantity")),
+			wantSQL: "`price` * `quantity`",
+		},
+		{
+			name:       "left operand of lower precedence",
+			expr:       Mul(Add(Col("price"), 1), 2),
+			wantSQL:    "(`price` + ?) * ?",
+			wantParams: []interface{}{1, 2},
+		},
+		{
+			name:       "left operand of higher precedence",
+			expr:       Sub(Mul(Col("price"), 2), Div(Col("discount"), 3)),
+			wantSQL:    "`price` * ? - `discount` / ?",
+			wantParams: []interface{}{2, 3},
+		},
+		{
+			name:       "left operand of same precedence",
+			expr:       Sub(Add(Col("a"), 1), 2),
+			wantSQL:    "`a` + ? - ?",
+			wantParams: []interface{}{1, 2},
+		},
+		{
+			name:       "right operand of same precedence",
+			expr:       Sub(Col("a"), Sub(Col("b"), 1)),
+			wantSQL:    "`a` - (`b` - ?)",
+			wantParams: []interface{}{1},
+		},
+		{
+			name:       "mod",
+			expr:       Mod(Add(Col("id"), 1), 10),
+			wantSQL:    "(`id` + ?) % ?",
+			wantParams: []interface{}{1, 10},
+		},
+		{
+			name:       "function and raw",
+			expr:       Add(Sum("amount"), Raw("a-b")),
+			wantSQL:    "SUM(`amount`) + (a-b)",
+			wantParams: nil,
+		},
+		{
+			name:       "raw with args",
+			expr:       Div(Raw("COALESCE(`a`, ?)", 0), Raw("`b` IS NULL")),
+			wantSQL:    "COALESCE(`a`, ?) / (`b` IS NULL)",
+			wantParams: []interface{}{0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotSQL, gotParams := tt.expr.RawSQL()
+			assertSQL(t, gotSQL, tt.wantSQL)
+			assertParams(t, gotParams, tt.wantParams)
+		})
+	}
+}
+
+func TestGenerator_Column(t *testing.T) {
+	ops := []Option{
+		WithAnd("updated_at", GT(Col("created_at"))),
+		WithAnd("balance", GTE(Add(Col("reserved"), 100))),
+		WithAnd("id", In([]interface{}{1, Col("parent_id")})),
+		WithAnd("score", Between(Col("min_score"), Mul(Col("max_score"), 2))),
+	}
+
+	g := NewGenerator("account", ops...)
+	gotSQL, gotParams := g.Select("id", Mul(Col("price"), Col("quantity")))
+	wantSQL := "SELECT `id`, `price` * `quantity` FROM `account` WHERE `updated_at`>`created_at` " +
+		"AND `balance`>=`reserved` + ? AND `id` IN (?,`parent_id`) AND `score` BETWEEN `min_score` AND `max_score` * ?"
+	assertSQL(t, gotSQL, wantSQL)
+	assertParams(t, gotParams, []interface{}{100, 1, 2})
+
+	// quoted with the dialect
+	g = NewGenerator("account", append(ops, WithDialect(Postgres))...)
+	gotSQL, gotParams = g.Select("id")
+	wantSQL = `SELECT "id" FROM "account" WHERE "updated_at">"created_at" ` +
+		`AND "balance">="reserved" + $1 AND "id" IN ($2,"parent_id") AND "score" BETWEEN "min_score" AND "max_score" * $3`
+	assertSQL(t, gotSQL, wantSQL)
+	assertParams(t, gotParams, []interface{}{100, 1, 2})
+
+	// update
+	m := NewAssExpr()
+	m.Put("balance", Sub(Col("balance"), 10))
+	m.Put("name", "tom")
+	gotSQL, gotParams = NewGenerator("account", WithAnd("id", EQ(1)), WithDialect(Postgres)).Update(m)
+	assertSQL(t, gotSQL, `UPDATE "account" SET "balance"="balance" - $1, "name"=$2 WHERE "id"=$3`)
+	assertParams(t, gotParams, []interface{}{10, "tom", 1})
+
+	// insert
+	gotSQL, gotParams = NewGenerator("account").Insert([]string{"name", "created_at"}, []interface{}{"tom", Raw("NOW()")})
+	assertSQL(t, gotSQL, "INSERT INTO `account` (`name`, `created_at`) VALUES (?,NOW())")
+	assertParams(t, gotParams, []interface{}{"tom"})
+
+	// validated by the Build methods
+	_, _, err := NewGenerator("account", WithAnd("balance", GT(Raw("? + ?", 1)))).BuildSelect("id")
+	if !errors.Is(err, ErrPlaceholderMismatch) {
+		t.Errorf("got error dose not meet the expected\nexpected: %v\n  actual: %v", ErrPlaceholderMismatch, err)
+	}
+
+	_, _, err = NewGenerator("account").BuildInsert([]string{"name"}, []interface{}{Raw("UPPER(?)")})
+	if !errors.Is(err, ErrPlaceholderMismatch) {
+		t.Errorf("got error dose not meet the expected\nexpected: %v\n  actual: %v", ErrPlaceholderMismatch, err)
+	}
+
+	// the column reference can not be formatted into the pattern of LIKE
+	_, _, err = NewGenerator("account", WithAnd("name", Like(Col("nickname")))).BuildSelect("id")
+	if !errors.Is(err, ErrTrustedLikeValue) {
+		t.Errorf("got error dose not meet the expected\nexpected: %v\n  actual: %v", ErrTrustedLikeValue, err)
+	}
+}
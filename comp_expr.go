@@ -2,56 +2,59 @@ package sqlg
 
 // CompExpr compound expression
 type CompExpr struct {
-	m map[string]Expr
-	s []string
+	m map[string]int
+	s []compItem
+}
+
+// compItem the column and expression of compound expression, the column can
+// be string or trusted expression
+type compItem struct {
+	column interface{}
+	expr   Expr
 }
 
 // NewCompExpr create compound expression
 func NewCompExpr() *CompExpr {
 	return &CompExpr{
-		m: map[string]Expr{},
-		s: []string{},
+		m: map[string]int{},
+		s: []compItem{},
 	}
 }
 
-// Put expression into the compound expression
-func (e *CompExpr) Put(column string, expr Expr) {
+// Put expression into the compound expression, the expression of the same
+// column name is replaced, the column of trusted expression is always
+// appended
+func (e *CompExpr) Put(column interface{}, expr Expr) {
 	if e == nil {
 		return
 	}
 
 	if e.m == nil {
-		e.m = map[string]Expr{}
+		e.m = map[string]int{}
 	}
 
-	if !e.exist(column) {
-		e.s = append(e.s, column)
+	name, ok := column.(string)
+	if !ok {
+		e.s = append(e.s, compItem{column: column, expr: expr})
+		return
 	}
 
-	e.m[column] = expr
-}
-
-func (e *CompExpr) exist(column string) bool {
-	if e.empty() {
-		return false
+	if i, exist := e.m[name]; exist {
+		e.s[i].expr = expr
+		return
 	}
 
-	_, exist := e.m[column]
-	return exist
+	e.m[name] = len(e.s)
+	e.s = append(e.s, compItem{column: column, expr: expr})
 }
 
-func (e *CompExpr) each(f func(column string, expr Expr)) {
+func (e *CompExpr) each(f func(column interface{}, expr Expr)) {
 	if e.empty() {
 		return
 	}
 
-	for _, column := range e.s {
-		e, exist := e.m[column]
-		if !exist {
-			continue
-		}
-
-		f(column, e)
+	for _, v := range e.s {
+		f(v.column, v.expr)
 	}
 }
 
@@ -60,9 +63,9 @@ func (e *CompExpr) size() int {
 		return 0
 	}
 
-	return len(e.m)
+	return len(e.s)
 }
 
 func (e *CompExpr) empty() bool {
-	return e == nil || len(e.m) == 0
+	return e == nil || len(e.s) == 0
 }
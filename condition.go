@@ -0,0 +1,110 @@
+package sqlg
+
+import (
+	"github.com/wwwangxc/sqlg/internal"
+	"github.com/wwwangxc/sqlg/internal/expr"
+)
+
+// Condition boolean expression tree, which is created by Cond, And, Or and
+// Not, and can be nested to any depth
+//
+// The nested conditions are parenthesized only when the precedence requires,
+// NOT binds tighter than AND, and AND binds tighter than OR.
+type Condition struct {
+	column interface{}
+	expr   Expr
+	join   internal.Operator
+	not    bool
+	conds  []*Condition
+}
+
+// Cond create the condition of column
+//
+// EXP:
+//
+//	Cond("age", GT(18))
+func Cond(column interface{}, expr Expr) *Condition {
+	return &Condition{
+		column: column,
+		expr:   expr,
+	}
+}
+
+// And join the conditions with AND
+//
+// EXP:
+//
+//	And(Cond("age", GT(18)), Cond("age", LT(65)))
+//	${cond1} AND ${cond2}
+func And(conds ...*Condition) *Condition {
+	return &Condition{
+		join:  internal.OperatorAnd,
+		conds: conds,
+	}
+}
+
+// Or join the conditions with OR
+//
+// EXP:
+//
+//	Or(Cond("status", EQ("vip")), Cond("score", GTE(90)))
+//	${cond1} OR ${cond2}
+func Or(conds ...*Condition) *Condition {
+	return &Condition{
+		join:  internal.OperatorOr,
+		conds: conds,
+	}
+}
+
+// Not negate the condition
+//
+// EXP:
+//
+//	Not(Or(Cond("status", EQ("banned")), Cond("deleted", EQ(1))))
+//	NOT (${cond})
+func Not(cond *Condition) *Condition {
+	return &Condition{
+		join:  internal.OperatorAnd,
+		not:   true,
+		conds: []*Condition{cond},
+	}
+}
+
+// empty will return true, when there is no condition of column in the tree
+func (c *Condition) empty() bool {
+	if c == nil {
+		return true
+	}
+
+	if c.conds == nil && c.join == internal.OperatorEmpty {
+		return false
+	}
+
+	for _, v := range c.conds {
+		if !v.empty() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// build return the expression joined with the previous expression by op
+func (c *Condition) build(op internal.Operator) internal.Expression {
+	if c.join == internal.OperatorEmpty {
+		return buildExpr(c.expr, op, c.column)
+	}
+
+	if c.not {
+		return expr.NewNot(op, c.conds[0].build(internal.OperatorAnd))
+	}
+
+	exprs := make([]internal.Expression, 0, len(c.conds))
+	for _, v := range c.conds {
+		if !v.empty() {
+			exprs = append(exprs, v.build(internal.OperatorAnd))
+		}
+	}
+
+	return expr.NewGroup(op, c.join, exprs...)
+}
@@ -0,0 +1,80 @@
+package sqlg
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestGenerator_SelectCondition(t *testing.T) {
+	// age > 18 AND age < 65 AND (vip OR score >= 90) AND NOT (banned OR deleted)
+	ops := []Option{
+		WithAnd("status", EQ(1)),
+		WithAndCond(And(
+			Cond("age", GT(18)),
+			Cond("age", LT(65)),
+			Or(Cond("vip", EQ(1)), Cond("score", GTE(90))),
+			Not(Or(Cond("banned", EQ(1)), Cond("deleted_at", NNull()))),
+		)),
+		WithOrCond(And(Cond("role", EQ("admin")), Not(Cond("name", Like("test"))))),
+	}
+
+	g := NewGenerator("user", ops...)
+	gotSQL, gotParams := g.Select("id")
+	wantSQL := "SELECT `id` FROM `user` WHERE `status`=? " +
+		"AND (`age`>? AND `age`<? AND (`vip`=? OR `score`>=?) AND NOT (`banned`=? OR `deleted_at` IS NOT NULL)) " +
+		"OR (`role`=? AND NOT (`name` LIKE ?))"
+	assertSQL(t, gotSQL, wantSQL)
+	assertParams(t, gotParams, []interface{}{1, 18, 65, 1, 90, 1, "admin", "%test%"})
+
+	// quoted with the dialect
+	g = NewGenerator("user", append(ops, WithDialect(Postgres))...)
+	gotSQL, _ = g.Select("id")
+	wantSQL = `SELECT "id" FROM "user" WHERE "status"=$1 ` +
+		`AND ("age">$2 AND "age"<$3 AND ("vip"=$4 OR "score">=$5) AND NOT ("banned"=$6 OR "deleted_at" IS NOT NULL)) ` +
+		`OR ("role"=$7 AND NOT ("name" LIKE $8))`
+	assertSQL(t, gotSQL, wantSQL)
+
+	// OR group joined with AND is parenthesized
+	g = NewGenerator("user", WithAnd("status", EQ(1)), WithAndCond(Or(Cond("a", EQ(1)), And(Cond("b", GT(2)), Cond("b", LT(5))))))
+	gotSQL, gotParams = g.Select("id")
+	assertSQL(t, gotSQL, "SELECT `id` FROM `user` WHERE `status`=? AND (`a`=? OR `b`>? AND `b`<?)")
+	assertParams(t, gotParams, []interface{}{1, 1, 2, 5})
+
+	// the first condition, the empty condition is ignored
+	g = NewGenerator("user", WithAndCond(And()), WithOrCond(nil), WithOrCond(Or(Cond("a", EQ(1)), Cond("b", EQ(2)))))
+	gotSQL, gotParams = g.Select("id")
+	assertSQL(t, gotSQL, "SELECT `id` FROM `user` WHERE (`a`=? OR `b`=?)")
+	assertParams(t, gotParams, []interface{}{1, 2})
+
+	// OR group between AND conditions is parenthesized
+	g = NewGenerator("user", WithAnd("x", EQ(0)), WithOrCond(Or(Cond("a", EQ(1)), Cond("b", EQ(2)))), WithAnd("c", EQ(3)))
+	gotSQL, gotParams = g.Select("id")
+	assertSQL(t, gotSQL, "SELECT `id` FROM `user` WHERE `x`=? OR (`a`=? OR `b`=?) AND `c`=?")
+	assertParams(t, gotParams, []interface{}{0, 1, 2, 3})
+
+	// raw condition
+	g = NewGenerator("user", WithAndCond(Not(Cond(Raw("JSON_CONTAINS(`tags`, ?)", `"go"`), nil))))
+	gotSQL, gotParams = g.Select("id")
+	assertSQL(t, gotSQL, "SELECT `id` FROM `user` WHERE NOT (JSON_CONTAINS(`tags`, ?))")
+	assertParams(t, gotParams, []interface{}{`"go"`})
+
+	// having
+	g = NewGenerator("order", WithGroupBy("user_id"), WithHaving(Count("*"), GT(1)),
+		WithHavingCond(Or(Cond(Sum("amount"), GT(100)), Cond(Max("amount"), GT(50)))),
+		WithOrHavingCond(Cond(Avg("amount"), GT(10))))
+	gotSQL, gotParams = g.Select("user_id")
+	assertSQL(t, gotSQL, "SELECT `user_id` FROM `order` GROUP BY `user_id` "+
+		"HAVING COUNT(*)>? AND (SUM(`amount`)>? OR MAX(`amount`)>?) OR AVG(`amount`)>?")
+	assertParams(t, gotParams, []interface{}{1, 100, 50, 10})
+
+	// validated by the Build methods
+	_, _, err := NewGenerator("user", WithAndCond(Or(Cond("a", EQ(1)), Not(Cond("b", In(nil)))))).BuildSelect("id")
+	if !errors.Is(err, ErrEmptyIn) {
+		t.Errorf("got error dose not meet the expected\nexpected: %v\n  actual: %v", ErrEmptyIn, err)
+	}
+
+	_, _, err = NewGenerator("user", WithHavingCond(And(Cond("a;", EQ(1))))).BuildSelect("id")
+	if !errors.Is(err, ErrUnsafeIdentifier) {
+		t.Errorf("got error dose not meet the expected\nexpected: %v\n  actual: %v", ErrUnsafeIdentifier, err)
+	}
+}
@@ -0,0 +1,725 @@
+package sqlg
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/wwwangxc/sqlg/internal"
+)
+
+var (
+	_ Dialect = (*mysql)(nil)
+	_ Dialect = (*postgres)(nil)
+	_ Dialect = (*sqlite)(nil)
+	_ Dialect = (*tsql)(nil)
+	_ Dialect = (*clickhouse)(nil)
+)
+
+var (
+	// MySQL dialect, quote identifier with backtick and use ? as placeholder
+	MySQL Dialect = &mysql{}
+
+	// Postgres dialect, quote identifier with double quote and use $1..$n as placeholder
+	Postgres Dialect = &postgres{}
+
+	// SQLite dialect, quote identifier with double quote and use ? as placeholder
+	SQLite Dialect = &sqlite{}
+
+	// TSQL dialect of SQL Server, quote identifier with square bracket and use @p1..@pn as placeholder
+	TSQL Dialect = &tsql{}
+
+	// ClickHouse dialect, quote identifier with backtick and use ? as placeholder
+	ClickHouse Dialect = &clickhouse{}
+)
+
+// Dialect of SQL statement
+//
+// Dialect decide how the identifiers are quoted, how the placeholders are
+// written and which clauses are used by the statements of generator.
+type Dialect interface {
+	// Name of dialect
+	Name() string
+
+	// Quote return quoted identifier
+	Quote(name string) string
+
+	// Placeholder return placeholder of the n-th param, n start from 1
+	Placeholder(n int) string
+
+	// genSelectWhere return the filter clauses of select statement
+	genSelectWhere(o *Options) (string, []interface{})
+
+	// genUpdate return update statement
+	genUpdate(g *Generator, assExpr *AssExpr) (string, []interface{})
+
+	// genDelete return delete statement
+	genDelete(g *Generator) (string, []interface{})
+
+	// genRecursive return the keyword of recursive common table expression
+	genRecursive() string
+
+	// genTableHints return the hints of the table
+	genTableHints(o *Options) string
+
+	// genTop return the TOP clause of statement
+	genTop(limit, offset uint32) string
+
+	// genPaging return the paging clause of statement, the top is true when
+	// the TOP clause is available in statement
+	genPaging(o *Options, top bool) string
+
+	// genSetMember return the member query of set operation which has its
+	// own ORDER BY or paging clause
+	genSetMember(sql string) string
+
+	// genForUpdate return the locking clause of select statement
+	genForUpdate() string
+
+	// genReturning return the OUTPUT clause and the RETURNING clause of statement
+	genReturning(o *Options, pseudo string) (string, string)
+
+	// genDual return the FROM clause of the INSERT ... SELECT statement
+	// without table
+	genDual() string
+
+	// genInsertInto return the beginning of insert statement
+	genInsertInto(mode insertMode) string
+
+	// genUpsert return the clause for resolving the conflict of insert
+	genUpsert(o *Options, columns []string) (string, []interface{})
+
+	// validateMutation return the error when the clauses of update or delete
+	// statement are not supported by dialect
+	validateMutation(o *Options) error
+
+	// validateInsert return the error when the clauses of insert statement
+	// are not supported by dialect
+	validateInsert(o *Options) error
+}
+
+// strictDialect the dialect in strict identifier mode
+type strictDialect struct {
+	Dialect
+}
+
+// Strict return true, the identifier will never be treated as expression
+func (s strictDialect) Strict() bool {
+	return true
+}
+
+type mysql struct{}
+
+// Name of dialect
+func (m *mysql) Name() string {
+	return "mysql"
+}
+
+// Quote return quoted identifier
+func (m *mysql) Quote(name string) string {
+	return internal.Quote(name, "`", "`")
+}
+
+// Placeholder return placeholder of the n-th param, n start from 1
+func (m *mysql) Placeholder(n int) string {
+	return "?"
+}
+
+func (m *mysql) genSelectWhere(o *Options) (string, []interface{}) {
+	return o.genFoldedWhere()
+}
+
+func (m *mysql) genUpdate(g *Generator, assExpr *AssExpr) (string, []interface{}) {
+	return g.genUpdate(assExpr)
+}
+
+func (m *mysql) genDelete(g *Generator) (string, []interface{}) {
+	return g.genDelete()
+}
+
+func (m *mysql) genRecursive() string {
+	return "RECURSIVE"
+}
+
+func (m *mysql) genTableHints(o *Options) string {
+	if o.forceIndex == "" {
+		return ""
+	}
+
+	return fmt.Sprintf("FORCE INDEX (%s)", internal.SafeName(o.dialect, o.forceIndex))
+}
+
+func (m *mysql) genTop(limit, offset uint32) string {
+	return ""
+}
+
+func (m *mysql) genPaging(o *Options, top bool) string {
+	return genLimitOffset(o)
+}
+
+func (m *mysql) genSetMember(sql string) string {
+	return fmt.Sprintf("(%s)", sql)
+}
+
+func (m *mysql) genForUpdate() string {
+	return "FOR UPDATE"
+}
+
+func (m *mysql) genReturning(o *Options, pseudo string) (string, string) {
+	return "", ""
+}
+
+func (m *mysql) genDual() string {
+	return "FROM dual"
+}
+
+func (m *mysql) genInsertInto(mode insertMode) string {
+	switch mode {
+	case insertModeReplace:
+		return "REPLACE INTO"
+	case insertModeIgnore:
+		return "INSERT IGNORE INTO"
+	default:
+		return "INSERT INTO"
+	}
+}
+
+func (m *mysql) genUpsert(o *Options, columns []string) (string, []interface{}) {
+	set, params := o.genUpsertAssignments("%s=VALUES(%s)", o.onConflictUpdate)
+	if set == "" {
+		return "", nil
+	}
+
+	return fmt.Sprintf("ON DUPLICATE KEY UPDATE %s", set), params
+}
+
+func (m *mysql) validateMutation(o *Options) error {
+	return nil
+}
+
+func (m *mysql) validateInsert(o *Options) error {
+	// REPLACE deletes the conflicting rows, which can not be updated
+	if o.insertMode == insertModeReplace && (!o.onDuplicateKeyUpdate.empty() || len(o.onConflictUpdate) > 0) {
+		return fmt.Errorf("%w: ON DUPLICATE KEY UPDATE of REPLACE statement", ErrUnsupportedClause)
+	}
+
+	return nil
+}
+
+type postgres struct{}
+
+// Name of dialect
+func (p *postgres) Name() string {
+	return "postgres"
+}
+
+// Quote return quoted identifier
+func (p *postgres) Quote(name string) string {
+	return internal.Quote(name, `"`, `"`)
+}
+
+// Placeholder return placeholder of the n-th param, n start from 1
+func (p *postgres) Placeholder(n int) string {
+	return fmt.Sprintf("$%d", n)
+}
+
+func (p *postgres) genSelectWhere(o *Options) (string, []interface{}) {
+	return o.genFoldedWhere()
+}
+
+func (p *postgres) genUpdate(g *Generator, assExpr *AssExpr) (string, []interface{}) {
+	return g.genUpdate(assExpr)
+}
+
+func (p *postgres) genDelete(g *Generator) (string, []interface{}) {
+	return g.genDelete()
+}
+
+func (p *postgres) genRecursive() string {
+	return "RECURSIVE"
+}
+
+func (p *postgres) genTableHints(o *Options) string {
+	return ""
+}
+
+func (p *postgres) genTop(limit, offset uint32) string {
+	return ""
+}
+
+func (p *postgres) genPaging(o *Options, top bool) string {
+	return genLimitOffset(o)
+}
+
+func (p *postgres) genSetMember(sql string) string {
+	return fmt.Sprintf("(%s)", sql)
+}
+
+func (p *postgres) genForUpdate() string {
+	return "FOR UPDATE"
+}
+
+func (p *postgres) genReturning(o *Options, pseudo string) (string, string) {
+	return "", genReturning(o)
+}
+
+func (p *postgres) genDual() string {
+	return ""
+}
+
+func (p *postgres) genInsertInto(mode insertMode) string {
+	return "INSERT INTO"
+}
+
+func (p *postgres) genUpsert(o *Options, columns []string) (string, []interface{}) {
+	excluded := o.onConflictUpdate
+	if o.insertMode == insertModeReplace {
+		target := map[string]bool{}
+		for _, column := range o.onConflict {
+			target[column] = true
+		}
+
+		excluded = append([]string{}, excluded...)
+		for _, column := range columns {
+			if !target[column] {
+				excluded = append(excluded, column)
+			}
+		}
+	}
+
+	return genOnConflict(o, excluded, o.insertMode == insertModeIgnore)
+}
+
+func (p *postgres) validateMutation(o *Options) error {
+	if len(o.orderBy) > 0 || o.limit > 0 || o.offset > 0 {
+		return fmt.Errorf("%w: ORDER BY, LIMIT and OFFSET of update and delete statement", ErrUnsupportedClause)
+	}
+
+	return nil
+}
+
+func (p *postgres) validateInsert(o *Options) error {
+	// the conflict target is optional for DO NOTHING only
+	update := !o.onDuplicateKeyUpdate.empty() || len(o.onConflictUpdate) > 0 || o.insertMode == insertModeReplace
+	if update && len(o.onConflict) == 0 {
+		return fmt.Errorf("%w: ON CONFLICT DO UPDATE requires the conflict target", ErrEmptyConflictTarget)
+	}
+
+	return validateConflictValues(o)
+}
+
+type sqlite struct{}
+
+// Name of dialect
+func (s *sqlite) Name() string {
+	return "sqlite"
+}
+
+// Quote return quoted identifier
+func (s *sqlite) Quote(name string) string {
+	return internal.Quote(name, `"`, `"`)
+}
+
+// Placeholder return placeholder of the n-th param, n start from 1
+func (s *sqlite) Placeholder(n int) string {
+	return "?"
+}
+
+func (s *sqlite) genSelectWhere(o *Options) (string, []interface{}) {
+	return o.genFoldedWhere()
+}
+
+func (s *sqlite) genUpdate(g *Generator, assExpr *AssExpr) (string, []interface{}) {
+	return g.genUpdate(assExpr)
+}
+
+func (s *sqlite) genDelete(g *Generator) (string, []interface{}) {
+	return g.genDelete()
+}
+
+func (s *sqlite) genRecursive() string {
+	return "RECURSIVE"
+}
+
+func (s *sqlite) genTableHints(o *Options) string {
+	if o.forceIndex == "" {
+		return ""
+	}
+
+	return fmt.Sprintf("INDEXED BY %s", internal.SafeName(o.dialect, o.forceIndex))
+}
+
+func (s *sqlite) genTop(limit, offset uint32) string {
+	return ""
+}
+
+func (s *sqlite) genPaging(o *Options, top bool) string {
+	return genLimitOffset(o)
+}
+
+func (s *sqlite) genSetMember(sql string) string {
+	return fmt.Sprintf("SELECT * FROM (%s)", sql)
+}
+
+func (s *sqlite) genForUpdate() string {
+	return ""
+}
+
+func (s *sqlite) genReturning(o *Options, pseudo string) (string, string) {
+	return "", genReturning(o)
+}
+
+func (s *sqlite) genDual() string {
+	return ""
+}
+
+func (s *sqlite) genInsertInto(mode insertMode) string {
+	switch mode {
+	case insertModeReplace:
+		return "INSERT OR REPLACE INTO"
+	case insertModeIgnore:
+		return "INSERT OR IGNORE INTO"
+	default:
+		return "INSERT INTO"
+	}
+}
+
+func (s *sqlite) genUpsert(o *Options, columns []string) (string, []interface{}) {
+	return genOnConflict(o, o.onConflictUpdate, false)
+}
+
+func (s *sqlite) validateMutation(o *Options) error {
+	return nil
+}
+
+func (s *sqlite) validateInsert(o *Options) error {
+	return validateConflictValues(o)
+}
+
+type tsql struct{}
+
+// Name of dialect
+func (t *tsql) Name() string {
+	return "tsql"
+}
+
+// Quote return quoted identifier
+func (t *tsql) Quote(name string) string {
+	return internal.Quote(name, "[", "]")
+}
+
+// Placeholder return placeholder of the n-th param, n start from 1
+func (t *tsql) Placeholder(n int) string {
+	return fmt.Sprintf("@p%d", n)
+}
+
+func (t *tsql) genSelectWhere(o *Options) (string, []interface{}) {
+	return o.genFoldedWhere()
+}
+
+func (t *tsql) genUpdate(g *Generator, assExpr *AssExpr) (string, []interface{}) {
+	return g.genUpdate(assExpr)
+}
+
+func (t *tsql) genDelete(g *Generator) (string, []interface{}) {
+	return g.genDelete()
+}
+
+func (t *tsql) genRecursive() string {
+	return ""
+}
+
+func (t *tsql) genTableHints(o *Options) string {
+	var hints []string
+	if o.forceIndex != "" {
+		hints = append(hints, fmt.Sprintf("INDEX(%s)", internal.SafeName(o.dialect, o.forceIndex)))
+	}
+
+	if o.forUpdate {
+		hints = append(hints, "UPDLOCK", "ROWLOCK")
+	}
+
+	if len(hints) == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("WITH (%s)", strings.Join(hints, ", "))
+}
+
+func (t *tsql) genTop(limit, offset uint32) string {
+	if offset > 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("TOP (%d)", limit)
+}
+
+func (t *tsql) genPaging(o *Options, top bool) string {
+	// the limit without offset is generated as TOP clause when it's available
+	if o.offset == 0 && (top || o.limit == 0) {
+		return ""
+	}
+
+	// OFFSET ... FETCH requires the ORDER BY clause
+	sql := fmt.Sprintf("OFFSET %d ROWS", o.offset)
+	if len(o.orderBy) == 0 {
+		sql = fmt.Sprintf("ORDER BY (SELECT NULL) %s", sql)
+	}
+
+	if o.limit > 0 {
+		sql = fmt.Sprintf("%s FETCH NEXT %d ROWS ONLY", sql, o.limit)
+	}
+
+	return sql
+}
+
+func (t *tsql) genSetMember(sql string) string {
+	return fmt.Sprintf("(%s)", sql)
+}
+
+func (t *tsql) genForUpdate() string {
+	return ""
+}
+
+func (t *tsql) genReturning(o *Options, pseudo string) (string, string) {
+	items := make([]string, 0, len(o.returning))
+	for _, column := range o.returning {
+		items = append(items, fmt.Sprintf("%s.%s", pseudo, internal.SafeName(o.dialect, column)))
+	}
+
+	return fmt.Sprintf("OUTPUT %s", strings.Join(items, ", ")), ""
+}
+
+func (t *tsql) genDual() string {
+	return ""
+}
+
+func (t *tsql) genInsertInto(mode insertMode) string {
+	return "INSERT INTO"
+}
+
+func (t *tsql) genUpsert(o *Options, columns []string) (string, []interface{}) {
+	return "", nil
+}
+
+// validateMutation return error when the rows of update or delete statement
+// are ordered or skipped, only the TOP clause is supported
+func (t *tsql) validateMutation(o *Options) error {
+	if len(o.orderBy) > 0 || o.offset > 0 {
+		return fmt.Errorf("%w: ORDER BY and OFFSET of update and delete statement", ErrUnsupportedClause)
+	}
+
+	return nil
+}
+
+// validateInsert reject the upsert and ignore clauses, T-SQL has no
+// equivalent of them except the MERGE statement
+func (t *tsql) validateInsert(o *Options) error {
+	if o.insertMode != insertModeDefault || upsert(o) {
+		return fmt.Errorf("%w: upsert and ignore of insert statement", ErrUnsupportedClause)
+	}
+
+	return nil
+}
+
+type clickhouse struct{}
+
+// Name of dialect
+func (c *clickhouse) Name() string {
+	return "clickhouse"
+}
+
+// Quote return quoted identifier
+func (c *clickhouse) Quote(name string) string {
+	return internal.Quote(name, "`", "`")
+}
+
+// Placeholder return placeholder of the n-th param, n start from 1
+func (c *clickhouse) Placeholder(n int) string {
+	return "?"
+}
+
+func (c *clickhouse) genSelectWhere(o *Options) (string, []interface{}) {
+	prewhere, params := o.genPrewhere()
+	where, whereParams := o.genWhere()
+	params = append(params, whereParams...)
+
+	return strings.TrimSpace(prewhere + sqlOrEmpty(where)), params
+}
+
+// genUpdate return update statement
+//
+// EXP:
+//
+//	ALTER TABLE ${table} UPDATE ${column}=${value} WHERE ${where}
+func (c *clickhouse) genUpdate(g *Generator, assExpr *AssExpr) (string, []interface{}) {
+	set, params := g.opts.genAssignments(assExpr)
+	where, whereParams := c.genMutationWhere(g.opts)
+	params = append(params, whereParams...)
+
+	return fmt.Sprintf("ALTER TABLE %s UPDATE %s %s", g.safeName(g.table), set, where), params
+}
+
+// genDelete return delete statement
+//
+// EXP:
+//
+//	ALTER TABLE ${table} DELETE WHERE ${where}
+func (c *clickhouse) genDelete(g *Generator) (string, []interface{}) {
+	where, params := c.genMutationWhere(g.opts)
+	return fmt.Sprintf("ALTER TABLE %s DELETE %s", g.safeName(g.table), where), params
+}
+
+// genMutationWhere return WHERE clause of mutation, which is required by
+// ClickHouse, the PREWHERE conditions are folded in
+func (c *clickhouse) genMutationWhere(o *Options) (string, []interface{}) {
+	where, params := o.genFoldedWhere()
+	if where == "" {
+		return "WHERE 1", nil
+	}
+
+	return where, params
+}
+
+func (c *clickhouse) genRecursive() string {
+	return "RECURSIVE"
+}
+
+func (c *clickhouse) genTableHints(o *Options) string {
+	var hints []string
+	if o.final {
+		hints = append(hints, "FINAL")
+	}
+
+	if o.sample > 0 {
+		hints = append(hints, fmt.Sprintf("SAMPLE %s", strconv.FormatFloat(o.sample, 'f', -1, 64)))
+	}
+
+	if len(o.arrayJoin) > 0 {
+		hints = append(hints, fmt.Sprintf("ARRAY JOIN %s", strings.Join(internal.SafeNames(o.dialect, o.arrayJoin), ", ")))
+	}
+
+	return strings.Join(hints, " ")
+}
+
+func (c *clickhouse) genTop(limit, offset uint32) string {
+	return ""
+}
+
+func (c *clickhouse) genPaging(o *Options, top bool) string {
+	return genLimitOffset(o)
+}
+
+func (c *clickhouse) genSetMember(sql string) string {
+	return fmt.Sprintf("(%s)", sql)
+}
+
+func (c *clickhouse) genForUpdate() string {
+	return ""
+}
+
+func (c *clickhouse) genReturning(o *Options, pseudo string) (string, string) {
+	return "", ""
+}
+
+func (c *clickhouse) genDual() string {
+	return ""
+}
+
+func (c *clickhouse) genInsertInto(mode insertMode) string {
+	return "INSERT INTO"
+}
+
+func (c *clickhouse) genUpsert(o *Options, columns []string) (string, []interface{}) {
+	return "", nil
+}
+
+// validateMutation reject the common table expressions, which can not be
+// referenced by ALTER TABLE statement, and the clauses limiting the rows
+// which are not supported by the mutations
+func (c *clickhouse) validateMutation(o *Options) error {
+	if len(o.ctes) > 0 {
+		return fmt.Errorf("%w: WITH of update and delete statement", ErrUnsupportedClause)
+	}
+
+	if len(o.orderBy) > 0 || o.limit > 0 || o.offset > 0 {
+		return fmt.Errorf("%w: ORDER BY, LIMIT and OFFSET of update and delete statement", ErrUnsupportedClause)
+	}
+
+	return nil
+}
+
+// validateInsert reject the upsert, ignore and RETURNING clauses, which are
+// not supported by ClickHouse
+func (c *clickhouse) validateInsert(o *Options) error {
+	if o.insertMode != insertModeDefault || upsert(o) {
+		return fmt.Errorf("%w: upsert and ignore of insert statement", ErrUnsupportedClause)
+	}
+
+	if len(o.returning) > 0 {
+		return fmt.Errorf("%w: RETURNING of insert statement", ErrUnsupportedClause)
+	}
+
+	return nil
+}
+
+// upsert return true when the insert statement has any upsert clause
+func upsert(o *Options) bool {
+	return !o.onDuplicateKeyUpdate.empty() || len(o.onConflict) > 0 || len(o.onConflictUpdate) > 0
+}
+
+// validateConflictValues return error when the assignment of ON CONFLICT
+// clause references VALUES function, which should be excluded.${column}
+func validateConflictValues(o *Options) error {
+	var err error
+	o.onDuplicateKeyUpdate.each(func(column string, value interface{}) {
+		if r, ok := value.(RawExpr); ok && r.values && err == nil {
+			err = fmt.Errorf("%w: VALUES function of ON CONFLICT clause, assign %s by OnConflictUpdate instead",
+				ErrUnsupportedClause, column)
+		}
+	})
+
+	return err
+}
+
+// genLimitOffset return LIMIT and OFFSET clause
+//
+// EXP:
+//
+//	LIMIT ${limit} OFFSET ${offset}
+func genLimitOffset(o *Options) string {
+	return strings.TrimSpace(o.genLimit() + sqlOrEmpty(o.genOffset()))
+}
+
+// genReturning return RETURNING clause
+//
+// EXP:
+//
+//	RETURNING ${column1}, ${column2}
+func genReturning(o *Options) string {
+	return fmt.Sprintf("RETURNING %s", strings.Join(internal.SafeNames(o.dialect, o.returning), ", "))
+}
+
+// genOnConflict return ON CONFLICT clause, the columns of excluded will be
+// set to the values proposed for insertion
+//
+// EXP:
+//
+//	ON CONFLICT (${column1}, ${column2}) DO UPDATE SET ${column}=${value}, ${column}=excluded.${column}
+//	ON CONFLICT (${column1}, ${column2}) DO NOTHING
+func genOnConflict(o *Options, excluded []string, doNothing bool) (string, []interface{}) {
+	target := ""
+	if len(o.onConflict) > 0 {
+		target = fmt.Sprintf(" (%s)", strings.Join(internal.SafeNames(o.dialect, o.onConflict), ", "))
+	}
+
+	set, params := o.genUpsertAssignments("%s=excluded.%s", excluded)
+	switch {
+	case set != "":
+		return fmt.Sprintf("ON CONFLICT%s DO UPDATE SET %s", target, set), params
+	case target != "", doNothing:
+		return fmt.Sprintf("ON CONFLICT%s DO NOTHING", target), nil
+	default:
+		return "", nil
+	}
+}
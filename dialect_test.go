@@ -0,0 +1,571 @@
+package sqlg
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPostgres_Select(t *testing.T) {
+	m := NewCompExpr()
+	m.Put("comp_col_eq", EQ("comp_val_eq"))
+	m.Put("comp_col_in", In([]interface{}{"comp_val_in1", "comp_val_in2"}))
+
+	exists := NewCompExpr()
+	exists.Put("exists_col_eq", EQ("exists_val_eq"))
+	exists.Put("exists_col_gt", GT("exists_val_gt"))
+
+	ops := []Option{
+		WithDialect(Postgres),
+		WithAnd("col_eq", EQ("val_eq")),
+		WithAnd("col_between", Between("val_between1", "val_between2")),
+		WithAndExprs(m),
+		WithExists("table_name1", exists),
+		WithOr("col_like", Like("val_like")),
+		WithGroupBy("col_group_by"),
+		WithOrderByDESC("col_order_by_desc"),
+		WithLimit(666),
+		WithOffset(999),
+		ForceIndex("idx_some_index"),
+		ForUpdate(),
+	}
+
+	g := NewGenerator("table_name", ops...)
+	gotSQL, gotParams := g.Select("col1", "col2")
+
+	wantSQL := `SELECT "col1", "col2" FROM "table_name" ` +
+		`WHERE "col_eq"=$1 ` +
+		`AND "col_between" BETWEEN $2 AND $3 ` +
+		`AND ("comp_col_eq"=$4 OR "comp_col_in" IN ($5,$6)) ` +
+		`AND EXISTS (SELECT * FROM "table_name1" WHERE "exists_col_eq"=$7 AND "exists_col_gt">$8) ` +
+		`OR "col_like" LIKE $9 ` +
+		`GROUP BY "col_group_by" ORDER BY "col_order_by_desc" DESC LIMIT 666 OFFSET 999 FOR UPDATE`
+	wantParams := []interface{}{"val_eq", "val_between1", "val_between2", "comp_val_eq", "comp_val_in1", "comp_val_in2",
+		"exists_val_eq", "exists_val_gt", "%val_like%"}
+
+	assertSQL(t, gotSQL, wantSQL)
+	assertParams(t, gotParams, wantParams)
+}
+
+func TestPostgres_Update(t *testing.T) {
+	g := NewGenerator("table_name", WithDialect(Postgres), WithAnd("col_eq", EQ("val_eq")), WithAnd("col_gt", GT("val_gt")))
+
+	assExpr := NewAssExpr()
+	assExpr.Put("col_1", "val_1")
+	assExpr.Put("col_2", "val_2")
+	gotSQL, gotParams := g.Update(assExpr)
+
+	wantSQL := `UPDATE "table_name" SET "col_1"=$1, "col_2"=$2 WHERE "col_eq"=$3 AND "col_gt">$4`
+	wantParams := []interface{}{"val_1", "val_2", "val_eq", "val_gt"}
+	assertSQL(t, gotSQL, wantSQL)
+	assertParams(t, gotParams, wantParams)
+
+	// ORDER BY and LIMIT are not supported
+	g = g.With(WithOrderBy("col_order_by"), WithLimit(3))
+	gotSQL, gotParams = g.Update(assExpr)
+	assertSQL(t, gotSQL, "")
+	assertParams(t, gotParams, nil)
+
+	_, _, err := g.BuildUpdate(assExpr)
+	if !errors.Is(err, ErrUnsupportedClause) {
+		t.Errorf("got error dose not meet the expected\nexpected: %v\n  actual: %v", ErrUnsupportedClause, err)
+	}
+}
+
+func TestPostgres_Delete(t *testing.T) {
+	g := NewGenerator("table_name", WithDialect(Postgres), WithAnd("col_eq", EQ("val_eq")), WithAnd("col_gt", GT("val_gt")),
+		Returning("col_1", "col_2"))
+	gotSQL, gotParams := g.Delete()
+
+	wantSQL := `DELETE FROM "table_name" WHERE "col_eq"=$1 AND "col_gt">$2 RETURNING "col_1", "col_2"`
+	wantParams := []interface{}{"val_eq", "val_gt"}
+	assertSQL(t, gotSQL, wantSQL)
+	assertParams(t, gotParams, wantParams)
+
+	// ORDER BY, LIMIT and OFFSET are not supported
+	for _, opt := range []Option{WithOrderBy("col_order_by"), WithLimit(3), WithOffset(2)} {
+		gotSQL, gotParams = g.With(opt).Delete()
+		assertSQL(t, gotSQL, "")
+		assertParams(t, gotParams, nil)
+
+		_, _, err := g.With(opt).BuildDelete()
+		if !errors.Is(err, ErrUnsupportedClause) {
+			t.Errorf("got error dose not meet the expected\nexpected: %v\n  actual: %v", ErrUnsupportedClause, err)
+		}
+	}
+}
+
+func TestPostgres_Insert(t *testing.T) {
+	columns := []string{"col_1", "col_2"}
+	records := [][]interface{}{
+		{"col_1_1", "col_2_1"},
+		{"col_1_2", "col_2_2"},
+	}
+
+	// INSERT INTO
+	g := NewGenerator("table_name", WithDialect(Postgres))
+	gotSQL, gotParams := g.Insert(columns, records...)
+	wantSQL := `INSERT INTO "table_name" ("col_1", "col_2") VALUES ($1,$2), ($3,$4)`
+	wantParams := []interface{}{"col_1_1", "col_2_1", "col_1_2", "col_2_2"}
+	assertSQL(t, gotSQL, wantSQL)
+	assertParams(t, gotParams, wantParams)
+
+	// INSERT INTO ON CONFLICT DO UPDATE
+	assExpr := NewAssExpr()
+	assExpr.Put("col_2", "val_2")
+	g = NewGenerator("table_name", WithDialect(Postgres), OnConflict("col_1"), OnDuplicateKeyUpdate(assExpr))
+	gotSQL, gotParams = g.Insert(columns, records...)
+	wantSQL = `INSERT INTO "table_name" ("col_1", "col_2") VALUES ($1,$2), ($3,$4) ON CONFLICT ("col_1") DO UPDATE SET "col_2"=$5`
+	wantParams = []interface{}{"col_1_1", "col_2_1", "col_1_2", "col_2_2", "val_2"}
+	assertSQL(t, gotSQL, wantSQL)
+	assertParams(t, gotParams, wantParams)
+
+	// INSERT INTO ON CONFLICT DO NOTHING
+	g = NewGenerator("table_name", WithDialect(Postgres), OnConflict("col_1"))
+	gotSQL, gotParams = g.Insert(columns, records...)
+	wantSQL = `INSERT INTO "table_name" ("col_1", "col_2") VALUES ($1,$2), ($3,$4) ON CONFLICT ("col_1") DO NOTHING`
+	wantParams = []interface{}{"col_1_1", "col_2_1", "col_1_2", "col_2_2"}
+	assertSQL(t, gotSQL, wantSQL)
+	assertParams(t, gotParams, wantParams)
+
+	// INSERT INTO WHERE NOT EXIST
+	exprCom := NewCompExpr()
+	exprCom.Put("col_1", EQ("val_eq"))
+	g = NewGenerator("table_name", WithDialect(Postgres), WithNExists("table_name", exprCom))
+	gotSQL, gotParams = g.Insert(columns, records...)
+	wantSQL = `INSERT INTO "table_name" ("col_1", "col_2") SELECT $1,$2 ` +
+		`WHERE NOT EXISTS (SELECT * FROM "table_name" WHERE "col_1"=$3)`
+	wantParams = []interface{}{"col_1_1", "col_2_1", "val_eq"}
+	assertSQL(t, gotSQL, wantSQL)
+	assertParams(t, gotParams, wantParams)
+}
+
+func TestPostgres_InsertOrReplace(t *testing.T) {
+	columns := []string{"col_1", "col_2", "col_3"}
+	records := [][]interface{}{{"col_1_1", "col_2_1", "col_3_1"}}
+
+	// INSERT INTO ON CONFLICT DO UPDATE SET excluded
+	g := NewGenerator("table_name", WithDialect(Postgres), OnConflict("col_1"), InsertOrReplace())
+	gotSQL, gotParams := g.Insert(columns, records...)
+	wantSQL := `INSERT INTO "table_name" ("col_1", "col_2", "col_3") VALUES ($1,$2,$3) ` +
+		`ON CONFLICT ("col_1") DO UPDATE SET "col_2"=excluded."col_2", "col_3"=excluded."col_3"`
+	wantParams := []interface{}{"col_1_1", "col_2_1", "col_3_1"}
+	assertSQL(t, gotSQL, wantSQL)
+	assertParams(t, gotParams, wantParams)
+
+	// INSERT INTO ON CONFLICT DO NOTHING
+	g = NewGenerator("table_name", WithDialect(Postgres), InsertOrIgnore())
+	gotSQL, gotParams = g.Insert(columns, records...)
+	wantSQL = `INSERT INTO "table_name" ("col_1", "col_2", "col_3") VALUES ($1,$2,$3) ON CONFLICT DO NOTHING`
+	assertSQL(t, gotSQL, wantSQL)
+	assertParams(t, gotParams, wantParams)
+
+	// DO UPDATE requires the conflict target
+	assExpr := NewAssExpr()
+	assExpr.Put("col_2", "val_2")
+	for _, opt := range []Option{InsertOrReplace(), OnConflictUpdate("col_2"), OnDuplicateKeyUpdate(assExpr)} {
+		g = NewGenerator("table_name", WithDialect(Postgres), opt)
+		gotSQL, gotParams = g.Insert(columns, records...)
+		assertSQL(t, gotSQL, "")
+		assertParams(t, gotParams, nil)
+
+		_, _, err := g.BuildInsert(columns, records...)
+		if !errors.Is(err, ErrEmptyConflictTarget) {
+			t.Errorf("got error dose not meet the expected\nexpected: %v\n  actual: %v", ErrEmptyConflictTarget, err)
+		}
+	}
+}
+
+func TestSQLite_Select(t *testing.T) {
+	g := NewGenerator("table_name",
+		WithDialect(SQLite),
+		WithAnd("col_eq", EQ("val_eq")),
+		WithAnd("col_in", In([]interface{}{"val_in1", "val_in2"})),
+		WithOrderBy("col_order_by"),
+		WithLimit(10),
+		WithOffset(20),
+		ForceIndex("idx_some_index"),
+		ForUpdate())
+	gotSQL, gotParams := g.Select("col1", "col2")
+
+	wantSQL := `SELECT "col1", "col2" FROM "table_name" INDEXED BY "idx_some_index" ` +
+		`WHERE "col_eq"=? AND "col_in" IN (?,?) ORDER BY "col_order_by" ASC LIMIT 10 OFFSET 20`
+	wantParams := []interface{}{"val_eq", "val_in1", "val_in2"}
+	assertSQL(t, gotSQL, wantSQL)
+	assertParams(t, gotParams, wantParams)
+}
+
+func TestSQLite_Insert(t *testing.T) {
+	columns := []string{"col_1", "col_2", "col_3"}
+	records := [][]interface{}{
+		{"col_1_1", "col_2_1", "col_3_1"},
+		{"col_1_2", "col_2_2", "col_3_2"},
+	}
+	wantParams := []interface{}{"col_1_1", "col_2_1", "col_3_1", "col_1_2", "col_2_2", "col_3_2"}
+
+	// INSERT OR REPLACE INTO
+	g := NewGenerator("table_name", WithDialect(SQLite), InsertOrReplace())
+	gotSQL, gotParams := g.Insert(columns, records...)
+	wantSQL := `INSERT OR REPLACE INTO "table_name" ("col_1", "col_2", "col_3") VALUES (?,?,?), (?,?,?)`
+	assertSQL(t, gotSQL, wantSQL)
+	assertParams(t, gotParams, wantParams)
+
+	// INSERT OR IGNORE INTO
+	g = NewGenerator("table_name", WithDialect(SQLite), InsertOrIgnore())
+	gotSQL, gotParams = g.Insert(columns, records...)
+	wantSQL = `INSERT OR IGNORE INTO "table_name" ("col_1", "col_2", "col_3") VALUES (?,?,?), (?,?,?)`
+	assertSQL(t, gotSQL, wantSQL)
+	assertParams(t, gotParams, wantParams)
+
+	// INSERT INTO ON CONFLICT DO UPDATE
+	assExpr := NewAssExpr()
+	assExpr.Put("col_3", "val_3")
+	g = NewGenerator("table_name", WithDialect(SQLite), OnConflict("col_1"), OnConflictUpdate("col_2"),
+		OnDuplicateKeyUpdate(assExpr))
+	gotSQL, gotParams = g.Insert(columns, records...)
+	wantSQL = `INSERT INTO "table_name" ("col_1", "col_2", "col_3") VALUES (?,?,?), (?,?,?) ` +
+		`ON CONFLICT ("col_1") DO UPDATE SET "col_3"=?, "col_2"=excluded."col_2"`
+	assertSQL(t, gotSQL, wantSQL)
+	assertParams(t, gotParams, append(wantParams, "val_3"))
+
+	// INSERT INTO WHERE NOT EXIST
+	exprCom := NewCompExpr()
+	exprCom.Put("col_1", EQ("val_eq"))
+	g = NewGenerator("table_name", WithDialect(SQLite), WithNExists("table_name", exprCom))
+	gotSQL, gotParams = g.Insert(columns, records...)
+	wantSQL = `INSERT INTO "table_name" ("col_1", "col_2", "col_3") SELECT ?,?,? ` +
+		`WHERE NOT EXISTS (SELECT * FROM "table_name" WHERE "col_1"=?)`
+	assertSQL(t, gotSQL, wantSQL)
+	assertParams(t, gotParams, []interface{}{"col_1_1", "col_2_1", "col_3_1", "val_eq"})
+}
+
+func TestTSQL_Select(t *testing.T) {
+	// SELECT TOP
+	g := NewGenerator("table_name",
+		WithDialect(TSQL),
+		WithAnd("col_eq", EQ("val_eq")),
+		WithAnd("col_in", In([]interface{}{"val_in1", "val_in2"})),
+		WithOrderByDESC("col_order_by"),
+		WithLimit(10),
+		ForceIndex("idx_some_index"),
+		ForUpdate())
+	gotSQL, gotParams := g.Select("col1", "col2")
+
+	wantSQL := "SELECT TOP (10) [col1], [col2] FROM [table_name] WITH (INDEX([idx_some_index]), UPDLOCK, ROWLOCK) " +
+		"WHERE [col_eq]=@p1 AND [col_in] IN (@p2,@p3) ORDER BY [col_order_by] DESC"
+	wantParams := []interface{}{"val_eq", "val_in1", "val_in2"}
+	assertSQL(t, gotSQL, wantSQL)
+	assertParams(t, gotParams, wantParams)
+
+	// SELECT OFFSET FETCH
+	g = NewGenerator("table_name",
+		WithDialect(TSQL),
+		WithAnd("col_eq", EQ("val_eq")),
+		WithOrderBy("col_order_by"),
+		WithLimit(10),
+		WithOffset(20))
+	gotSQL, gotParams = g.Select()
+
+	wantSQL = "SELECT * FROM [table_name] WHERE [col_eq]=@p1 ORDER BY [col_order_by] ASC OFFSET 20 ROWS FETCH NEXT 10 ROWS ONLY"
+	assertSQL(t, gotSQL, wantSQL)
+	assertParams(t, gotParams, []interface{}{"val_eq"})
+
+	// SELECT OFFSET without ORDER BY
+	g = NewGenerator("table_name", WithDialect(TSQL), WithOffset(20))
+	gotSQL, gotParams = g.Select()
+
+	wantSQL = "SELECT * FROM [table_name] ORDER BY (SELECT NULL) OFFSET 20 ROWS"
+	assertSQL(t, gotSQL, wantSQL)
+	assertParams(t, gotParams, nil)
+
+	// recursive CTE without RECURSIVE keyword
+	on := NewCompExpr()
+	on.Put("c.parent_id", EQColumn("t.id"))
+	anchor := NewGenerator("category", WithColumns("id", "parent_id"), WithAnd("id", EQ(1)))
+	recursive := NewGenerator("category", WithColumns("c.id", "c.parent_id"), WithAlias("c"), WithJoin("tree", "t", on))
+	g = NewGenerator("tree", WithDialect(TSQL), WithRecursiveCTE("tree", anchor, recursive), WithLimit(10))
+	gotSQL, gotParams = g.Select("id")
+
+	wantSQL = "WITH [tree] AS (SELECT [id], [parent_id] FROM [category] WHERE [id]=@p1 UNION ALL " +
+		"SELECT [c].[id], [c].[parent_id] FROM [category] AS [c] INNER JOIN [tree] AS [t] ON [c].[parent_id]=[t].[id]) " +
+		"SELECT TOP (10) [id] FROM [tree]"
+	assertSQL(t, gotSQL, wantSQL)
+	assertParams(t, gotParams, []interface{}{1})
+}
+
+func TestTSQL_Update(t *testing.T) {
+	g := NewGenerator("table_name", WithDialect(TSQL), WithAnd("col_eq", EQ("val_eq")), WithLimit(1), Returning("col_1"))
+
+	assExpr := NewAssExpr()
+	assExpr.Put("col_1", "val_1")
+	gotSQL, gotParams := g.Update(assExpr)
+
+	wantSQL := "UPDATE TOP (1) [table_name] SET [col_1]=@p1 OUTPUT inserted.[col_1] WHERE [col_eq]=@p2"
+	wantParams := []interface{}{"val_1", "val_eq"}
+	assertSQL(t, gotSQL, wantSQL)
+	assertParams(t, gotParams, wantParams)
+
+	// only TOP is supported
+	for _, opt := range []Option{WithOrderBy("col_order_by"), WithOffset(2)} {
+		gotSQL, gotParams = g.With(opt).Update(assExpr)
+		assertSQL(t, gotSQL, "")
+		assertParams(t, gotParams, nil)
+
+		_, _, err := g.With(opt).BuildUpdate(assExpr)
+		if !errors.Is(err, ErrUnsupportedClause) {
+			t.Errorf("got error dose not meet the expected\nexpected: %v\n  actual: %v", ErrUnsupportedClause, err)
+		}
+	}
+}
+
+func TestTSQL_Delete(t *testing.T) {
+	g := NewGenerator("table_name", WithDialect(TSQL), WithAnd("col_eq", EQ("val_eq")), WithLimit(1))
+	gotSQL, gotParams := g.Delete()
+
+	wantSQL := "DELETE TOP (1) FROM [table_name] WHERE [col_eq]=@p1"
+	assertSQL(t, gotSQL, wantSQL)
+	assertParams(t, gotParams, []interface{}{"val_eq"})
+
+	// with CTE
+	expired := NewGenerator("session", WithColumns("id"), WithAnd("expired", EQ(true)))
+	gotSQL, gotParams = g.With(WithCTE("expired", expired), WithAnd("id", InQuery(NewGenerator("expired"), "id"))).Delete()
+
+	wantSQL = "WITH [expired] AS (SELECT [id] FROM [session] WHERE [expired]=@p1) " +
+		"DELETE TOP (1) FROM [table_name] WHERE [col_eq]=@p2 AND [id] IN (SELECT [id] FROM [expired])"
+	assertSQL(t, gotSQL, wantSQL)
+	assertParams(t, gotParams, []interface{}{true, "val_eq"})
+
+	// only TOP is supported
+	for _, opt := range []Option{WithOrderBy("col_order_by"), WithOffset(2)} {
+		gotSQL, gotParams = g.With(opt).Delete()
+		assertSQL(t, gotSQL, "")
+		assertParams(t, gotParams, nil)
+
+		_, _, err := g.With(opt).BuildDelete()
+		if !errors.Is(err, ErrUnsupportedClause) {
+			t.Errorf("got error dose not meet the expected\nexpected: %v\n  actual: %v", ErrUnsupportedClause, err)
+		}
+	}
+}
+
+func TestTSQL_Insert(t *testing.T) {
+	columns := []string{"col_1", "col_2"}
+	records := [][]interface{}{
+		{"col_1_1", "col_2_1"},
+		{"col_1_2", "col_2_2"},
+	}
+
+	// INSERT INTO OUTPUT
+	g := NewGenerator("table_name", WithDialect(TSQL), Returning())
+	gotSQL, gotParams := g.Insert(columns, records...)
+	wantSQL := "INSERT INTO [table_name] ([col_1], [col_2]) OUTPUT inserted.* VALUES (@p1,@p2), (@p3,@p4)"
+	wantParams := []interface{}{"col_1_1", "col_2_1", "col_1_2", "col_2_2"}
+	assertSQL(t, gotSQL, wantSQL)
+	assertParams(t, gotParams, wantParams)
+
+	// INSERT INTO WHERE NOT EXIST
+	exprCom := NewCompExpr()
+	exprCom.Put("col_1", EQ("val_eq"))
+	g = NewGenerator("table_name", WithDialect(TSQL), WithNExists("table_name", exprCom))
+	gotSQL, gotParams = g.Insert(columns, records...)
+	wantSQL = "INSERT INTO [table_name] ([col_1], [col_2]) SELECT @p1,@p2 " +
+		"WHERE NOT EXISTS (SELECT * FROM [table_name] WHERE [col_1]=@p3)"
+	assertSQL(t, gotSQL, wantSQL)
+	assertParams(t, gotParams, []interface{}{"col_1_1", "col_2_1", "val_eq"})
+
+	// upsert and ignore are not supported
+	assExpr := NewAssExpr()
+	assExpr.Put("col_2", "val_2")
+	opts := []Option{
+		InsertOrIgnore(),
+		InsertOrReplace(),
+		OnDuplicateKeyUpdate(assExpr),
+		OnConflict("col_1"),
+		OnConflictUpdate("col_2"),
+	}
+
+	g = NewGenerator("table_name", WithDialect(TSQL))
+	for _, opt := range opts {
+		gotSQL, gotParams = g.With(opt).Insert(columns, records...)
+		assertSQL(t, gotSQL, "")
+		assertParams(t, gotParams, nil)
+
+		if _, _, err := g.With(opt).BuildInsert(columns, records...); !errors.Is(err, ErrUnsupportedClause) {
+			t.Errorf("got error dose not meet the expected\nexpected: %v\n  actual: %v", ErrUnsupportedClause, err)
+		}
+	}
+}
+
+func TestClickHouse_Select(t *testing.T) {
+	g := NewGenerator("table_name",
+		WithDialect(ClickHouse),
+		WithFinal(),
+		WithSample(0.1),
+		WithArrayJoin("col_arr"),
+		WithPrewhere("col_pre_eq", EQ("val_pre_eq")),
+		WithOrPrewhere("col_pre_gt", GT("val_pre_gt")),
+		WithAnd("col_eq", EQ("val_eq")),
+		WithOrderByDESC("col_order_by"),
+		WithLimit(10),
+		WithOffset(20),
+		ForceIndex("idx_some_index"),
+		ForUpdate())
+	gotSQL, gotParams := g.Select("col1", "col_arr")
+
+	wantSQL := "SELECT `col1`, `col_arr` FROM `table_name` FINAL SAMPLE 0.1 ARRAY JOIN `col_arr` " +
+		"PREWHERE `col_pre_eq`=? OR `col_pre_gt`>? WHERE `col_eq`=? ORDER BY `col_order_by` DESC LIMIT 10 OFFSET 20"
+	wantParams := []interface{}{"val_pre_eq", "val_pre_gt", "val_eq"}
+	assertSQL(t, gotSQL, wantSQL)
+	assertParams(t, gotParams, wantParams)
+
+	// fold PREWHERE into WHERE for other dialects
+	g = NewGenerator("table_name",
+		WithFinal(),
+		WithPrewhere("col_pre_eq", EQ("val_pre_eq")),
+		WithOrPrewhere("col_pre_gt", GT("val_pre_gt")),
+		WithAnd("col_eq", EQ("val_eq")),
+		WithOr("col_lt", LT("val_lt")))
+	gotSQL, gotParams = g.Select()
+
+	wantSQL = "SELECT * FROM `table_name` WHERE (`col_pre_eq`=? OR `col_pre_gt`>?) AND (`col_eq`=? OR `col_lt`<?)"
+	wantParams = []interface{}{"val_pre_eq", "val_pre_gt", "val_eq", "val_lt"}
+	assertSQL(t, gotSQL, wantSQL)
+	assertParams(t, gotParams, wantParams)
+}
+
+func TestClickHouse_Update(t *testing.T) {
+	assExpr := NewAssExpr()
+	assExpr.Put("col_1", "val_1")
+	assExpr.Put("col_2", "val_2")
+
+	g := NewGenerator("table_name", WithDialect(ClickHouse), WithAnd("col_eq", EQ("val_eq")))
+	gotSQL, gotParams := g.Update(assExpr)
+	wantSQL := "ALTER TABLE `table_name` UPDATE `col_1`=?, `col_2`=? WHERE `col_eq`=?"
+	wantParams := []interface{}{"val_1", "val_2", "val_eq"}
+	assertSQL(t, gotSQL, wantSQL)
+	assertParams(t, gotParams, wantParams)
+
+	// the mutation can not be ordered or limited
+	for _, opt := range []Option{WithOrderBy("col_order_by"), WithLimit(1), WithOffset(2)} {
+		gotSQL, gotParams = g.With(opt).Update(assExpr)
+		assertSQL(t, gotSQL, "")
+		assertParams(t, gotParams, nil)
+
+		if _, _, err := g.With(opt).BuildUpdate(assExpr); !errors.Is(err, ErrUnsupportedClause) {
+			t.Errorf("got error dose not meet the expected\nexpected: %v\n  actual: %v", ErrUnsupportedClause, err)
+		}
+
+		if _, _, err := g.With(opt).BuildDelete(); !errors.Is(err, ErrUnsupportedClause) {
+			t.Errorf("got error dose not meet the expected\nexpected: %v\n  actual: %v", ErrUnsupportedClause, err)
+		}
+	}
+
+	g = NewGenerator("table_name", WithDialect(ClickHouse))
+	gotSQL, gotParams = g.Update(assExpr)
+	wantSQL = "ALTER TABLE `table_name` UPDATE `col_1`=?, `col_2`=? WHERE 1"
+	wantParams = []interface{}{"val_1", "val_2"}
+	assertSQL(t, gotSQL, wantSQL)
+	assertParams(t, gotParams, wantParams)
+
+	// fold PREWHERE into WHERE
+	g = NewGenerator("table_name", WithDialect(ClickHouse), WithPrewhere("col_pre_eq", EQ("val_pre_eq")))
+	gotSQL, gotParams = g.Update(assExpr)
+	wantSQL = "ALTER TABLE `table_name` UPDATE `col_1`=?, `col_2`=? WHERE `col_pre_eq`=?"
+	wantParams = []interface{}{"val_1", "val_2", "val_pre_eq"}
+	assertSQL(t, gotSQL, wantSQL)
+	assertParams(t, gotParams, wantParams)
+}
+
+func TestClickHouse_Delete(t *testing.T) {
+	g := NewGenerator("table_name", WithDialect(ClickHouse), WithAnd("col_eq", EQ("val_eq")), WithAnd("col_gt", GT("val_gt")))
+	gotSQL, gotParams := g.Delete()
+	wantSQL := "ALTER TABLE `table_name` DELETE WHERE `col_eq`=? AND `col_gt`>?"
+	assertSQL(t, gotSQL, wantSQL)
+	assertParams(t, gotParams, []interface{}{"val_eq", "val_gt"})
+
+	// fold PREWHERE into WHERE
+	g = NewGenerator("table_name", WithDialect(ClickHouse), WithPrewhere("col_pre_eq", EQ("val_pre_eq")),
+		WithAnd("col_eq", EQ("val_eq")))
+	gotSQL, gotParams = g.Delete()
+	wantSQL = "ALTER TABLE `table_name` DELETE WHERE `col_pre_eq`=? AND `col_eq`=?"
+	assertSQL(t, gotSQL, wantSQL)
+	assertParams(t, gotParams, []interface{}{"val_pre_eq", "val_eq"})
+
+	// CTE can not be referenced by mutation
+	g = g.With(WithCTE("expired", NewGenerator("session", WithColumns("id"))))
+	gotSQL, gotParams = g.Delete()
+	assertSQL(t, gotSQL, "")
+	assertParams(t, gotParams, nil)
+
+	if _, _, err := g.BuildDelete(); !errors.Is(err, ErrUnsupportedClause) {
+		t.Errorf("got error dose not meet the expected\nexpected: %v\n  actual: %v", ErrUnsupportedClause, err)
+	}
+
+	assExpr := NewAssExpr()
+	assExpr.Put("col_1", "val_1")
+	if _, _, err := g.BuildUpdate(assExpr); !errors.Is(err, ErrUnsupportedClause) {
+		t.Errorf("got error dose not meet the expected\nexpected: %v\n  actual: %v", ErrUnsupportedClause, err)
+	}
+}
+
+func TestClickHouse_Insert(t *testing.T) {
+	columns := []string{"col_1", "col_2"}
+	records := [][]interface{}{
+		{"col_1_1", "col_2_1"},
+		{"col_1_2", "col_2_2"},
+	}
+
+	g := NewGenerator("table_name", WithDialect(ClickHouse))
+	gotSQL, gotParams := g.Insert(columns, records...)
+	wantSQL := "INSERT INTO `table_name` (`col_1`, `col_2`) VALUES (?,?), (?,?)"
+	wantParams := []interface{}{"col_1_1", "col_2_1", "col_1_2", "col_2_2"}
+	assertSQL(t, gotSQL, wantSQL)
+	assertParams(t, gotParams, wantParams)
+
+	// upsert, ignore and RETURNING are not supported
+	assExpr := NewAssExpr()
+	assExpr.Put("col_2", "val_2")
+	opts := []Option{
+		InsertOrIgnore(),
+		InsertOrReplace(),
+		OnDuplicateKeyUpdate(assExpr),
+		OnConflict("col_1"),
+		OnConflictUpdate("col_2"),
+		Returning(),
+	}
+
+	for _, opt := range opts {
+		gotSQL, gotParams = g.With(opt).Insert(columns, records...)
+		assertSQL(t, gotSQL, "")
+		assertParams(t, gotParams, nil)
+
+		if _, _, err := g.With(opt).BuildInsert(columns, records...); !errors.Is(err, ErrUnsupportedClause) {
+			t.Errorf("got error dose not meet the expected\nexpected: %v\n  actual: %v", ErrUnsupportedClause, err)
+		}
+	}
+}
+
+func TestPrewhere_Mutation(t *testing.T) {
+	assExpr := NewAssExpr()
+	assExpr.Put("col_1", "val_1")
+
+	// the PREWHERE condition is kept by update and delete statements
+	g := NewGenerator("table_name", WithPrewhere("col_pre_eq", EQ("val_pre_eq")))
+	gotSQL, gotParams := g.Update(assExpr)
+	assertSQL(t, gotSQL, "UPDATE `table_name` SET `col_1`=? WHERE `col_pre_eq`=?")
+	assertParams(t, gotParams, []interface{}{"val_1", "val_pre_eq"})
+
+	gotSQL, gotParams = g.Delete()
+	assertSQL(t, gotSQL, "DELETE FROM `table_name` WHERE `col_pre_eq`=?")
+	assertParams(t, gotParams, []interface{}{"val_pre_eq"})
+
+	g = NewGenerator("table_name", WithDialect(Postgres), WithPrewhere("col_pre_eq", EQ("val_pre_eq")),
+		WithOr("col_eq", EQ("val_eq")))
+	gotSQL, gotParams = g.Delete()
+	assertSQL(t, gotSQL, `DELETE FROM "table_name" WHERE "col_pre_eq"=$1 AND "col_eq"=$2`)
+	assertParams(t, gotParams, []interface{}{"val_pre_eq", "val_eq"})
+
+	// and by conditional insert statement
+	gotSQL, gotParams = g.Insert([]string{"col_1"}, []interface{}{"val_1"})
+	assertSQL(t, gotSQL, `INSERT INTO "table_name" ("col_1") SELECT $1 WHERE "col_pre_eq"=$2 AND "col_eq"=$3`)
+	assertParams(t, gotParams, []interface{}{"val_1", "val_pre_eq", "val_eq"})
+}
@@ -0,0 +1,69 @@
+package sqlg
+
+import (
+	"errors"
+
+	"github.com/wwwangxc/sqlg/internal"
+)
+
+var (
+	// ErrEmptyIn the values of IN expression is empty, such as In([]interface{}{})
+	ErrEmptyIn = internal.ErrEmptyIn
+
+	// ErrUnsafeIdentifier the table、column or index name contains statement
+	// terminator, comment or NUL character
+	ErrUnsafeIdentifier = internal.ErrUnsafeIdentifier
+
+	// ErrPlaceholderMismatch the count of placeholders of Raw is different
+	// from the args, or the args of Raw can not be bound
+	ErrPlaceholderMismatch = internal.ErrPlaceholderMismatch
+
+	// ErrTrustedLikeValue the value of LIKE expression is trusted expression
+	// such as Col, which can not be formatted into the pattern
+	ErrTrustedLikeValue = internal.ErrTrustedLikeValue
+
+	// ErrEmptyAssignment the assignment expression of update statement is nil
+	// or empty
+	ErrEmptyAssignment = errors.New("sqlg: empty assignment expression")
+
+	// ErrRecordLengthMismatch the length of insert record is different from
+	// the columns
+	ErrRecordLengthMismatch = errors.New("sqlg: record length mismatch columns")
+
+	// ErrEmptyColumns the columns of insert statement is empty
+	ErrEmptyColumns = errors.New("sqlg: empty columns")
+
+	// ErrEmptyRecords the records of insert statement is empty
+	ErrEmptyRecords = errors.New("sqlg: empty records")
+
+	// ErrInconsistentRecords the autoincr or omitempty column is zero value
+	// in some of the records only, which can not be skipped by all of them
+	ErrInconsistentRecords = errors.New("sqlg: inconsistent records")
+
+	// ErrMultipleRecords the conditional insert statement can only insert one
+	// record
+	ErrMultipleRecords = errors.New("sqlg: multiple records of conditional insert")
+
+	// ErrUnsupportedTarget the target of struct mapping is not structure,
+	// pointer or slice of them
+	ErrUnsupportedTarget = errors.New("sqlg: unsupported target")
+
+	// ErrNilGenerator the generator is nil
+	ErrNilGenerator = errors.New("sqlg: nil generator")
+
+	// ErrDerivedGenerator the generator of derived table or set operation
+	// can only generate select statement
+	ErrDerivedGenerator = errors.New("sqlg: derived generator can only generate select statement")
+
+	// ErrEmptyAlias the alias of derived table is empty, which is required
+	// by the FROM clause
+	ErrEmptyAlias = errors.New("sqlg: empty alias of derived table")
+
+	// ErrUnsupportedClause the clause can not be generated by the statement
+	// of dialect, such as ORDER BY and LIMIT of postgres DELETE statement
+	ErrUnsupportedClause = errors.New("sqlg: unsupported clause")
+
+	// ErrEmptyConflictTarget the conflict target of ON CONFLICT DO UPDATE
+	// clause is empty, which is required by postgres
+	ErrEmptyConflictTarget = errors.New("sqlg: empty conflict target")
+)
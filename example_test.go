@@ -1,6 +1,7 @@
 package sqlg_test
 
 import (
+	"database/sql"
 	"fmt"
 
 	"github.com/wwwangxc/sqlg"
@@ -380,3 +381,27 @@ func ExampleGenerator_Insert() {
 	// [tom 5 tom 5]
 
 }
+
+func ExampleGenerator_InsertByStruct() {
+	type User struct {
+		ID        uint64         `db:"id,autoincr"`
+		Name      string         `db:"name"`
+		Nickname  sql.NullString `db:"nickname,omitempty"`
+		CreatedAt string         `db:"created_at,readonly"`
+	}
+
+	// generate INSERT sql statement and params
+	g := sqlg.NewGenerator("user")
+	query, params, err := g.InsertByStruct([]User{
+		{Name: "tom", Nickname: sql.NullString{String: "cat", Valid: true}},
+		{Name: "jerry", Nickname: sql.NullString{String: "mouse", Valid: true}},
+	})
+	fmt.Println(query)
+	fmt.Println(params)
+	fmt.Println(err)
+
+	// Output:
+	// INSERT INTO `user` (`name`, `nickname`) VALUES (?,?), (?,?)
+	// [tom cat jerry mouse]
+	// <nil>
+}
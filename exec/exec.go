@@ -0,0 +1,180 @@
+// Package exec execute the statements of sqlg over database/sql, the
+// statements can be executed by *sql.DB, *sql.Tx and *sql.Conn
+package exec
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/wwwangxc/sqlg"
+)
+
+var (
+	_ Executor = (*sql.DB)(nil)
+	_ Executor = (*sql.Tx)(nil)
+	_ Executor = (*sql.Conn)(nil)
+)
+
+// ErrEmptyStatement the generated statement is empty
+var ErrEmptyStatement = errors.New("sqlg/exec: empty statement")
+
+// Executor execute the statement, which is implemented by *sql.DB, *sql.Tx
+// and *sql.Conn
+type Executor interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// Error of execution, which wrap the error with the generated statement
+type Error struct {
+	// SQL the generated statement
+	SQL string
+
+	// Err the error of generating or executing
+	Err error
+}
+
+// Error return the error message with the generated statement
+func (e *Error) Error() string {
+	if e.SQL == "" {
+		return fmt.Sprintf("sqlg/exec: %v", e.Err)
+	}
+
+	return fmt.Sprintf("sqlg/exec: %v: %s", e.Err, e.SQL)
+}
+
+// Unwrap return the wrapped error
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+func wrapError(query string, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return &Error{SQL: query, Err: err}
+}
+
+// Statement generated by the generator, the error of generating is returned
+// when it's executed
+type Statement struct {
+	query  string
+	params []interface{}
+	err    error
+}
+
+// NewStatement create statement with the results of the generator
+//
+// EXP:
+//
+//	NewStatement(g.InsertByStruct(user))
+func NewStatement(query string, params []interface{}, err error) Statement {
+	return Statement{
+		query:  query,
+		params: params,
+		err:    err,
+	}
+}
+
+// Select statement
+func Select(g *sqlg.Generator, columns ...interface{}) Statement {
+	return NewStatement(g.BuildSelect(columns...))
+}
+
+// Update statement
+func Update(g *sqlg.Generator, assExpr *sqlg.AssExpr) Statement {
+	return NewStatement(g.BuildUpdate(assExpr))
+}
+
+// Delete statement
+func Delete(g *sqlg.Generator) Statement {
+	return NewStatement(g.BuildDelete())
+}
+
+// Insert statement
+func Insert(g *sqlg.Generator, columns []string, records ...[]interface{}) Statement {
+	return NewStatement(g.BuildInsert(columns, records...))
+}
+
+// SQL return the generated statement
+func (s Statement) SQL() string {
+	return s.query
+}
+
+// Params return the params of generated statement
+func (s Statement) Params() []interface{} {
+	return s.params
+}
+
+// Err return the error of generating
+func (s Statement) Err() error {
+	switch {
+	case s.err != nil:
+		return s.err
+	case s.query == "":
+		return ErrEmptyStatement
+	default:
+		return nil
+	}
+}
+
+// ExecContext execute the statement without returning rows, such as INSERT,
+// UPDATE and DELETE
+func ExecContext(ctx context.Context, db Executor, s Statement) (sql.Result, error) {
+	if err := s.Err(); err != nil {
+		return nil, wrapError(s.query, err)
+	}
+
+	result, err := db.ExecContext(ctx, s.query, s.params...)
+	return result, wrapError(s.query, err)
+}
+
+// QueryContext execute the statement that return rows, such as SELECT
+func QueryContext(ctx context.Context, db Executor, s Statement) (*sql.Rows, error) {
+	if err := s.Err(); err != nil {
+		return nil, wrapError(s.query, err)
+	}
+
+	rows, err := db.QueryContext(ctx, s.query, s.params...)
+	return rows, wrapError(s.query, err)
+}
+
+// QueryRowContext execute the statement that is expected to return at most
+// one row, the error is deferred until Row's Scan method is called
+func QueryRowContext(ctx context.Context, db Executor, s Statement) *Row {
+	if err := s.Err(); err != nil {
+		return &Row{query: s.query, err: err}
+	}
+
+	return &Row{query: s.query, row: db.QueryRowContext(ctx, s.query, s.params...)}
+}
+
+// Row the result of QueryRowContext
+type Row struct {
+	query string
+	row   *sql.Row
+	err   error
+}
+
+// Scan copy the columns of the row into the values pointed at by dest,
+// sql.ErrNoRows is wrapped when the query selects no rows
+func (r *Row) Scan(dest ...interface{}) error {
+	if err := r.Err(); err != nil {
+		return err
+	}
+
+	return wrapError(r.query, r.row.Scan(dest...))
+}
+
+// Err return the error of generating or executing without scanning
+func (r *Row) Err() error {
+	if r.err != nil {
+		return wrapError(r.query, r.err)
+	}
+
+	return wrapError(r.query, r.row.Err())
+}
@@ -0,0 +1,294 @@
+package exec
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/wwwangxc/sqlg"
+)
+
+// fakeDriver record the executed statements, the query contains `fail`
+// return error, and the select statement return the rows of fakeRows
+type fakeDriver struct {
+	mu       sync.Mutex
+	executed []string
+	params   [][]driver.Value
+}
+
+var fake = &fakeDriver{}
+
+func init() {
+	sql.Register("sqlg-fake", fake)
+}
+
+func (d *fakeDriver) Open(name string) (driver.Conn, error) {
+	return &fakeConn{}, nil
+}
+
+func (d *fakeDriver) record(query string, args []driver.Value) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.executed = append(d.executed, query)
+	d.params = append(d.params, args)
+	if strings.Contains(query, "fail") {
+		return errors.New("fake error")
+	}
+
+	return nil
+}
+
+func (d *fakeDriver) last() (string, []driver.Value) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if len(d.executed) == 0 {
+		return "", nil
+	}
+
+	return d.executed[len(d.executed)-1], d.params[len(d.params)-1]
+}
+
+type fakeConn struct{}
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) { return &fakeStmt{query: query}, nil }
+func (c *fakeConn) Close() error                              { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error)                 { return &fakeTx{}, nil }
+
+type fakeTx struct{}
+
+func (t *fakeTx) Commit() error   { return nil }
+func (t *fakeTx) Rollback() error { return nil }
+
+type fakeStmt struct {
+	query string
+}
+
+func (s *fakeStmt) Close() error  { return nil }
+func (s *fakeStmt) NumInput() int { return -1 }
+
+func (s *fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	if err := fake.record(s.query, args); err != nil {
+		return nil, err
+	}
+
+	return fakeResult{}, nil
+}
+
+// fakeResult affect one row, and the last insert id is always 666
+type fakeResult struct{}
+
+func (fakeResult) LastInsertId() (int64, error) { return 666, nil }
+func (fakeResult) RowsAffected() (int64, error) { return 1, nil }
+
+func (s *fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	if err := fake.record(s.query, args); err != nil {
+		return nil, err
+	}
+
+	for table, rows := range fakeTables {
+		if strings.Contains(s.query, "`"+table+"`") || strings.Contains(s.query, `"`+table+`"`) {
+			return &fakeRows{columns: rows.columns, values: rows.values}, nil
+		}
+	}
+
+	return &fakeRows{columns: []string{"id", "name"}, values: [][]driver.Value{{int64(1), "tom"}, {int64(2), "jerry"}}}, nil
+}
+
+// fakeTables the rows returned by the select statement of table
+var fakeTables = map[string]*fakeRows{
+	"empty": {columns: []string{"id", "name"}},
+}
+
+type fakeRows struct {
+	columns []string
+	values  [][]driver.Value
+}
+
+func (r *fakeRows) Columns() []string { return r.columns }
+func (r *fakeRows) Close() error      { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+
+	copy(dest, r.values[0])
+	r.values = r.values[1:]
+	return nil
+}
+
+func openDB(t *testing.T) *sql.DB {
+	db, err := sql.Open("sqlg-fake", "")
+	if err != nil {
+		t.Fatalf("open fake db: %v", err)
+	}
+
+	t.Cleanup(func() { _ = db.Close() })
+	return db
+}
+
+func assertExecuted(t *testing.T, wantSQL string, wantParams []driver.Value) {
+	t.Helper()
+	gotSQL, gotParams := fake.last()
+	if gotSQL != wantSQL {
+		t.Errorf("executed sql got = %v, want %v", gotSQL, wantSQL)
+	}
+
+	if !reflect.DeepEqual(gotParams, wantParams) {
+		t.Errorf("executed params got = %v, want %v", gotParams, wantParams)
+	}
+}
+
+func TestExecContext(t *testing.T) {
+	ctx := context.Background()
+	db := openDB(t)
+	g := sqlg.NewGenerator("user", sqlg.WithAnd("id", sqlg.EQ(1)))
+
+	assExpr := sqlg.NewAssExpr()
+	assExpr.Put("name", "tom")
+	result, err := ExecContext(ctx, db, Update(g, assExpr))
+	if err != nil {
+		t.Fatalf("ExecContext() error = %v", err)
+	}
+
+	if n, _ := result.RowsAffected(); n != 1 {
+		t.Errorf("RowsAffected() got = %v, want 1", n)
+	}
+	assertExecuted(t, "UPDATE `user` SET `name`=? WHERE `id`=?", []driver.Value{"tom", int64(1)})
+
+	// transaction
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		t.Fatalf("BeginTx() error = %v", err)
+	}
+
+	if _, err = ExecContext(ctx, tx, Delete(g)); err != nil {
+		t.Fatalf("ExecContext() error = %v", err)
+	}
+	assertExecuted(t, "DELETE FROM `user` WHERE `id`=?", []driver.Value{int64(1)})
+	_ = tx.Rollback()
+
+	// connection
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		t.Fatalf("Conn() error = %v", err)
+	}
+	defer conn.Close()
+
+	if _, err = ExecContext(ctx, conn, Insert(sqlg.NewGenerator("user", sqlg.WithDialect(sqlg.Postgres)), []string{"name"}, []interface{}{"tom"})); err != nil {
+		t.Fatalf("ExecContext() error = %v", err)
+	}
+	assertExecuted(t, `INSERT INTO "user" ("name") VALUES ($1)`, []driver.Value{"tom"})
+
+	type user struct {
+		ID   int64  `db:"id,autoincr"`
+		Name string `db:"name"`
+	}
+
+	if _, err = ExecContext(ctx, db, NewStatement(sqlg.NewGenerator("user").InsertByStruct(user{Name: "jerry"}))); err != nil {
+		t.Fatalf("ExecContext() error = %v", err)
+	}
+	assertExecuted(t, "INSERT INTO `user` (`name`) VALUES (?)", []driver.Value{"jerry"})
+}
+
+func TestExecContext_Error(t *testing.T) {
+	ctx := context.Background()
+	db := openDB(t)
+
+	// the error of executing is wrapped with the statement
+	_, err := ExecContext(ctx, db, Delete(sqlg.NewGenerator("fail")))
+	var execErr *Error
+	if !errors.As(err, &execErr) {
+		t.Fatalf("ExecContext() error = %v, want *Error", err)
+	}
+
+	if execErr.SQL != "DELETE FROM `fail`" || execErr.Err.Error() != "fake error" {
+		t.Errorf("ExecContext() error = %v", err)
+	}
+
+	if err.Error() != "sqlg/exec: fake error: DELETE FROM `fail`" {
+		t.Errorf("Error() got = %v", err.Error())
+	}
+
+	// the error of generating is returned without executing
+	_, err = ExecContext(ctx, db, Update(sqlg.NewGenerator("user"), nil))
+	if !errors.Is(err, sqlg.ErrEmptyAssignment) {
+		t.Errorf("ExecContext() error = %v, want %v", err, sqlg.ErrEmptyAssignment)
+	}
+
+	_, err = ExecContext(ctx, db, NewStatement("", nil, nil))
+	if !errors.Is(err, ErrEmptyStatement) {
+		t.Errorf("ExecContext() error = %v, want %v", err, ErrEmptyStatement)
+	}
+}
+
+func TestQueryContext(t *testing.T) {
+	ctx := context.Background()
+	db := openDB(t)
+
+	g := sqlg.NewGenerator("user", sqlg.WithAnd("age", sqlg.GT(18)))
+	rows, err := QueryContext(ctx, db, Select(g, "id", "name"))
+	if err != nil {
+		t.Fatalf("QueryContext() error = %v", err)
+	}
+	defer rows.Close()
+	assertExecuted(t, "SELECT `id`, `name` FROM `user` WHERE `age`>?", []driver.Value{int64(18)})
+
+	var names []string
+	for rows.Next() {
+		var id int64
+		var name string
+		if err := rows.Scan(&id, &name); err != nil {
+			t.Fatalf("Scan() error = %v", err)
+		}
+
+		names = append(names, name)
+	}
+
+	if !reflect.DeepEqual(names, []string{"tom", "jerry"}) {
+		t.Errorf("rows got = %v, want [tom jerry]", names)
+	}
+
+	_, err = QueryContext(ctx, db, Select(sqlg.NewGenerator("fail")))
+	if !errors.As(err, new(*Error)) || !strings.Contains(err.Error(), "SELECT * FROM `fail`") {
+		t.Errorf("QueryContext() error = %v", err)
+	}
+
+	_, err = QueryContext(ctx, db, Select(sqlg.NewGenerator("user;")))
+	if !errors.Is(err, sqlg.ErrUnsafeIdentifier) {
+		t.Errorf("QueryContext() error = %v, want %v", err, sqlg.ErrUnsafeIdentifier)
+	}
+}
+
+func TestQueryRowContext(t *testing.T) {
+	ctx := context.Background()
+	db := openDB(t)
+
+	var id int64
+	var name string
+	err := QueryRowContext(ctx, db, Select(sqlg.NewGenerator("user"), "id", "name")).Scan(&id, &name)
+	if err != nil || id != 1 || name != "tom" {
+		t.Errorf("Scan() got = %v %v, error = %v", id, name, err)
+	}
+
+	err = QueryRowContext(ctx, db, Select(sqlg.NewGenerator("empty"), "id", "name")).Scan(&id, &name)
+	if !errors.Is(err, sql.ErrNoRows) || !strings.Contains(err.Error(), "SELECT `id`, `name` FROM `empty`") {
+		t.Errorf("Scan() error = %v, want %v", err, sql.ErrNoRows)
+	}
+
+	row := QueryRowContext(ctx, db, Select(sqlg.NewGenerator("fail")))
+	if err := row.Err(); !errors.As(err, new(*Error)) {
+		t.Errorf("Err() error = %v", err)
+	}
+
+	row = QueryRowContext(ctx, db, Select(nil))
+	if err := row.Scan(&id); !errors.Is(err, sqlg.ErrNilGenerator) {
+		t.Errorf("Scan() error = %v, want %v", err, sqlg.ErrNilGenerator)
+	}
+}
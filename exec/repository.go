@@ -0,0 +1,227 @@
+package exec
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/wwwangxc/sqlg"
+	"github.com/wwwangxc/sqlg/internal"
+)
+
+var (
+	// ErrNoPrimaryKey the structure has no primary key, which is the field
+	// with the pk or autoincr option of the tag `db`
+	ErrNoPrimaryKey = errors.New("sqlg/exec: no primary key")
+
+	// ErrEmptyCondition the delete statement has no condition, use DeleteAll
+	// to delete all the rows
+	ErrEmptyCondition = errors.New("sqlg/exec: empty condition")
+)
+
+// Repository the typed CRUD of the table, the rows are mapped into T by the
+// tag `db`, and filtered by the options of sqlg
+//
+// EXP:
+//
+//	users := NewRepository[User](db, "user")
+//	list, err := users.Find(ctx, sqlg.WithAnd("age", sqlg.GT(18)))
+type Repository[T any] struct {
+	db    Executor
+	table string
+	opts  []sqlg.Option
+}
+
+// NewRepository create the repository of the table, the options are applied
+// to all statements, such as the dialect
+func NewRepository[T any](db Executor, table string, opts ...sqlg.Option) *Repository[T] {
+	return &Repository[T]{
+		db:    db,
+		table: table,
+		opts:  opts,
+	}
+}
+
+// Find select the rows filtered by the options
+func (r *Repository[T]) Find(ctx context.Context, opts ...sqlg.Option) ([]T, error) {
+	var list []T
+	if err := SelectInto(ctx, r.db, r.generator(opts...), &list); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// First select the first row filtered by the options, sql.ErrNoRows is
+// wrapped when there is no row
+func (r *Repository[T]) First(ctx context.Context, opts ...sqlg.Option) (*T, error) {
+	v := new(T)
+	if err := GetInto(ctx, r.db, r.generator(opts...), v); err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+// Count return the count of rows filtered by the options
+func (r *Repository[T]) Count(ctx context.Context, opts ...sqlg.Option) (int64, error) {
+	var n int64
+	if err := QueryRowContext(ctx, r.db, Select(r.generator(opts...), sqlg.Count("*"))).Scan(&n); err != nil {
+		return 0, err
+	}
+
+	return n, nil
+}
+
+// Create insert the row, the last insert id is assigned to the zero autoincr
+// field when it's supported by the driver
+func (r *Repository[T]) Create(ctx context.Context, v *T) error {
+	if v == nil {
+		return fmt.Errorf("%w: %T", sqlg.ErrUnsupportedTarget, v)
+	}
+
+	result, err := ExecContext(ctx, r.db, NewStatement(r.generator().InsertByStruct(v)))
+	if err != nil {
+		return err
+	}
+
+	field, ok := autoIncrField(reflect.ValueOf(v).Elem())
+	if !ok {
+		return nil
+	}
+
+	// the driver such as postgres does not support the last insert id
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		field.SetInt(id)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		field.SetUint(uint64(id))
+	}
+
+	return nil
+}
+
+// CreateBatch insert the rows by one statement, and return the count of
+// inserted rows
+func (r *Repository[T]) CreateBatch(ctx context.Context, list []T) (int64, error) {
+	if len(list) == 0 {
+		return 0, sqlg.ErrEmptyRecords
+	}
+
+	result, err := ExecContext(ctx, r.db, NewStatement(r.generator().InsertByStruct(list)))
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+// Update update the row by the primary key, the primary key columns are not
+// assigned, and return the count of affected rows
+//
+// EXP:
+//
+//	users.Update(ctx, user, sqlg.OmitColumns("created_at"))
+func (r *Repository[T]) Update(ctx context.Context, v *T, opts ...sqlg.StructOption) (int64, error) {
+	if v == nil {
+		return 0, fmt.Errorf("%w: %T", sqlg.ErrUnsupportedTarget, v)
+	}
+
+	conds, columns, err := primaryKey(reflect.ValueOf(v).Elem())
+	if err != nil {
+		return 0, err
+	}
+
+	opts = append([]sqlg.StructOption{sqlg.OmitColumns(columns...)}, opts...)
+	result, err := ExecContext(ctx, r.db, NewStatement(r.generator(conds...).UpdateByStruct(v, opts...)))
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+// Delete delete the rows filtered by the options, and return the count of
+// deleted rows, ErrEmptyCondition is returned when the options have no
+// condition
+func (r *Repository[T]) Delete(ctx context.Context, opts ...sqlg.Option) (int64, error) {
+	if !sqlg.NewGenerator(r.table, opts...).HasCondition() {
+		return 0, ErrEmptyCondition
+	}
+
+	return r.delete(ctx, opts...)
+}
+
+// DeleteAll delete all the rows, and return the count of deleted rows
+func (r *Repository[T]) DeleteAll(ctx context.Context) (int64, error) {
+	return r.delete(ctx)
+}
+
+func (r *Repository[T]) delete(ctx context.Context, opts ...sqlg.Option) (int64, error) {
+	result, err := ExecContext(ctx, r.db, Delete(r.generator(opts...)))
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+func (r *Repository[T]) generator(opts ...sqlg.Option) *sqlg.Generator {
+	return sqlg.NewGenerator(r.table, r.opts...).With(opts...)
+}
+
+// primaryKey return the conditions and the columns of primary key
+func primaryKey(v reflect.Value) ([]sqlg.Option, []string, error) {
+	info := internal.GetStructInfo(v.Type())
+	if len(info.PrimaryKey) == 0 {
+		return nil, nil, fmt.Errorf("%w: %s", ErrNoPrimaryKey, v.Type())
+	}
+
+	conds := make([]sqlg.Option, 0, len(info.PrimaryKey))
+	columns := make([]string, 0, len(info.PrimaryKey))
+	for _, f := range info.PrimaryKey {
+		field, ok := internal.FieldByIndex(v, f.Index)
+		if !ok {
+			return nil, nil, fmt.Errorf("%w: %q of %s can not be read", ErrNoPrimaryKey, f.Column, v.Type())
+		}
+
+		conds = append(conds, sqlg.WithAnd(f.Column, sqlg.EQ(field.Interface())))
+		columns = append(columns, f.Column)
+	}
+
+	return conds, columns, nil
+}
+
+// autoIncrField return the only autoincr field when it's zero value
+func autoIncrField(v reflect.Value) (reflect.Value, bool) {
+	var found *internal.Field
+	info := internal.GetStructInfo(v.Type())
+	for i, f := range info.Fields {
+		if !f.AutoIncr {
+			continue
+		}
+
+		if found != nil {
+			return reflect.Value{}, false
+		}
+
+		found = &info.Fields[i]
+	}
+
+	if found == nil {
+		return reflect.Value{}, false
+	}
+
+	field, ok := internal.FieldByIndex(v, found.Index)
+	if !ok || !field.CanSet() || !field.IsZero() {
+		return reflect.Value{}, false
+	}
+
+	return field, true
+}
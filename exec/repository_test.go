@@ -0,0 +1,191 @@
+package exec
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"testing"
+
+	"github.com/wwwangxc/sqlg"
+)
+
+func init() {
+	fakeTables["counter"] = &fakeRows{
+		columns: []string{"COUNT(*)"},
+		values:  [][]driver.Value{{int64(2)}},
+	}
+}
+
+type account struct {
+	Tenant string `db:"tenant,pk"`
+	Name   string `db:"name,pk"`
+	Secret string `db:"secret"`
+}
+
+func TestRepository_Find(t *testing.T) {
+	ctx := context.Background()
+	members := NewRepository[member](openDB(t), "member")
+
+	list, err := members.Find(ctx, sqlg.WithAnd("id", sqlg.GT(0)), sqlg.WithOrderBy("id"))
+	if err != nil {
+		t.Fatalf("Find() error = %v", err)
+	}
+	assertExecuted(t, "SELECT `id`, `name`, `nickname`, `age` FROM `member` WHERE `id`>? ORDER BY `id` ASC", []driver.Value{int64(0)})
+
+	if len(list) != 2 || list[0].ID != 1 || list[1].Name != "jerry" {
+		t.Errorf("Find() got = %+v", list)
+	}
+
+	m, err := members.First(ctx, sqlg.WithAnd("id", sqlg.EQ(1)))
+	if err != nil {
+		t.Fatalf("First() error = %v", err)
+	}
+	assertExecuted(t, "SELECT `id`, `name`, `nickname`, `age` FROM `member` WHERE `id`=? LIMIT 1", []driver.Value{int64(1)})
+
+	if m.ID != 1 || m.Name != "tom" {
+		t.Errorf("First() got = %+v", m)
+	}
+
+	if _, err = NewRepository[member](openDB(t), "empty").First(ctx); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("First() error = %v, want %v", err, sql.ErrNoRows)
+	}
+}
+
+func TestRepository_Count(t *testing.T) {
+	ctx := context.Background()
+	counter := NewRepository[member](openDB(t), "counter", sqlg.WithDialect(sqlg.Postgres))
+
+	n, err := counter.Count(ctx, sqlg.WithAnd("age", sqlg.GTE(18)))
+	if err != nil {
+		t.Fatalf("Count() error = %v", err)
+	}
+	assertExecuted(t, `SELECT COUNT(*) FROM "counter" WHERE "age">=$1`, []driver.Value{int64(18)})
+
+	if n != 2 {
+		t.Errorf("Count() got = %v, want 2", n)
+	}
+}
+
+func TestRepository_Create(t *testing.T) {
+	ctx := context.Background()
+	members := NewRepository[member](openDB(t), "member")
+
+	// the last insert id is assigned to the autoincr field
+	m := &member{Base: &Base{}, Name: "tom"}
+	if err := members.Create(ctx, m); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	assertExecuted(t, "INSERT INTO `member` (`name`, `nickname`, `age`) VALUES (?,?,?)", []driver.Value{"tom", nil, nil})
+
+	if m.ID != 666 {
+		t.Errorf("Create() got id = %v, want 666", m.ID)
+	}
+
+	n, err := members.CreateBatch(ctx, []member{{Base: &Base{ID: 1}, Name: "tom"}, {Base: &Base{ID: 2}, Name: "jerry"}})
+	if err != nil {
+		t.Fatalf("CreateBatch() error = %v", err)
+	}
+	assertExecuted(t, "INSERT INTO `member` (`id`, `name`, `nickname`, `age`) VALUES (?,?,?,?), (?,?,?,?)",
+		[]driver.Value{int64(1), "tom", nil, nil, int64(2), "jerry", nil, nil})
+
+	if n != 1 {
+		t.Errorf("CreateBatch() got = %v, want 1", n)
+	}
+
+	if err = members.Create(ctx, nil); !errors.Is(err, sqlg.ErrUnsupportedTarget) {
+		t.Errorf("Create() error = %v, want %v", err, sqlg.ErrUnsupportedTarget)
+	}
+
+	if _, err = members.CreateBatch(ctx, nil); !errors.Is(err, sqlg.ErrEmptyRecords) {
+		t.Errorf("CreateBatch() error = %v, want %v", err, sqlg.ErrEmptyRecords)
+	}
+
+	// the error of statement comes through
+	if err = NewRepository[member](openDB(t), "member; drop").Create(ctx, &member{Name: "tom"}); !errors.Is(err, sqlg.ErrUnsafeIdentifier) {
+		t.Errorf("Create() error = %v, want %v", err, sqlg.ErrUnsafeIdentifier)
+	}
+
+	_, err = members.CreateBatch(ctx, []member{{Base: &Base{ID: 1}, Name: "tom"}, {Name: "jerry"}})
+	if !errors.Is(err, sqlg.ErrInconsistentRecords) {
+		t.Errorf("CreateBatch() error = %v, want %v", err, sqlg.ErrInconsistentRecords)
+	}
+}
+
+func TestRepository_Update(t *testing.T) {
+	ctx := context.Background()
+	db := openDB(t)
+
+	// the autoincr column is primary key
+	members := NewRepository[member](db, "member")
+	if _, err := members.Update(ctx, &member{Base: &Base{ID: 1}, Name: "tom"}, sqlg.OmitColumns("age")); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	assertExecuted(t, "UPDATE `member` SET `name`=?, `nickname`=? WHERE `id`=?", []driver.Value{"tom", nil, int64(1)})
+
+	// composite primary key
+	accounts := NewRepository[account](db, "account")
+	n, err := accounts.Update(ctx, &account{Tenant: "t1", Name: "tom", Secret: "***"})
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	assertExecuted(t, "UPDATE `account` SET `secret`=? WHERE `tenant`=? AND `name`=?", []driver.Value{"***", "t1", "tom"})
+
+	if n != 1 {
+		t.Errorf("Update() got = %v, want 1", n)
+	}
+
+	// no primary key
+	type log struct {
+		Message string `db:"message"`
+	}
+
+	if _, err = NewRepository[log](db, "log").Update(ctx, &log{}); !errors.Is(err, ErrNoPrimaryKey) {
+		t.Errorf("Update() error = %v, want %v", err, ErrNoPrimaryKey)
+	}
+
+	// the primary key under nil embedded pointer
+	if _, err = members.Update(ctx, &member{Name: "tom"}); !errors.Is(err, ErrNoPrimaryKey) {
+		t.Errorf("Update() error = %v, want %v", err, ErrNoPrimaryKey)
+	}
+
+	// the error of statement comes through
+	limited := NewRepository[member](db, "member", sqlg.WithDialect(sqlg.Postgres), sqlg.WithLimit(1))
+	if _, err = limited.Update(ctx, &member{Base: &Base{ID: 1}, Name: "tom"}); !errors.Is(err, sqlg.ErrUnsupportedClause) {
+		t.Errorf("Update() error = %v, want %v", err, sqlg.ErrUnsupportedClause)
+	}
+}
+
+func TestRepository_Delete(t *testing.T) {
+	ctx := context.Background()
+	members := NewRepository[member](openDB(t), "member")
+
+	n, err := members.Delete(ctx, sqlg.WithAnd("id", sqlg.In([]interface{}{1, 2})))
+	if err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	assertExecuted(t, "DELETE FROM `member` WHERE `id` IN (?,?)", []driver.Value{int64(1), int64(2)})
+
+	if n != 1 {
+		t.Errorf("Delete() got = %v, want 1", n)
+	}
+
+	// the condition is required
+	if _, err = members.Delete(ctx, sqlg.WithLimit(1)); !errors.Is(err, ErrEmptyCondition) {
+		t.Errorf("Delete() error = %v, want %v", err, ErrEmptyCondition)
+	}
+
+	n, err = members.DeleteAll(ctx)
+	if err != nil {
+		t.Fatalf("DeleteAll() error = %v", err)
+	}
+	assertExecuted(t, "DELETE FROM `member`", []driver.Value{})
+
+	if n != 1 {
+		t.Errorf("DeleteAll() got = %v, want 1", n)
+	}
+
+	if _, err = NewRepository[member](openDB(t), "fail").DeleteAll(ctx); !errors.As(err, new(*Error)) {
+		t.Errorf("DeleteAll() error = %v", err)
+	}
+}
@@ -0,0 +1,185 @@
+package exec
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/wwwangxc/sqlg"
+	"github.com/wwwangxc/sqlg/internal"
+)
+
+// ErrUnmappedColumn the column of result can not be mapped into the field of
+// structure
+var ErrUnmappedColumn = errors.New("sqlg/exec: unmapped column")
+
+// SelectInto select the columns of the tag `db` of dest, and scan the rows
+// into dest, which must be the pointer to slice of structure or pointer
+//
+// EXP:
+//
+//	var users []User
+//	SelectInto(ctx, db, g, &users)
+func SelectInto(ctx context.Context, db Executor, g *sqlg.Generator, dest interface{}) error {
+	s := NewStatement(g.SelectByStruct(dest))
+	rows, err := QueryContext(ctx, db, s)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	return wrapError(s.query, ScanAll(rows, dest))
+}
+
+// GetInto select the columns of the tag `db` of dest with LIMIT 1, and scan
+// the row into dest, which must be the pointer to structure, sql.ErrNoRows
+// is wrapped when the query selects no rows
+//
+// EXP:
+//
+//	var user User
+//	GetInto(ctx, db, g, &user)
+func GetInto(ctx context.Context, db Executor, g *sqlg.Generator, dest interface{}) error {
+	s := NewStatement(g.With(sqlg.WithLimit(1)).SelectByStruct(dest))
+	rows, err := QueryContext(ctx, db, s)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	return wrapError(s.query, ScanOne(rows, dest))
+}
+
+// ScanAll scan the rows into dest by the tag `db`, dest must be the pointer
+// to slice of structure or pointer
+//
+// The NULL can be scanned into the pointer field or sql.Scanner, the fields
+// of embedded structure are flattened, and ErrUnmappedColumn is returned
+// when there is no field of the column.
+func ScanAll(rows *sql.Rows, dest interface{}) error {
+	value := reflect.ValueOf(dest)
+	if value.Kind() != reflect.Ptr || value.IsNil() || value.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("%w: %T", sqlg.ErrUnsupportedTarget, dest)
+	}
+
+	slice := value.Elem()
+	elemType := slice.Type().Elem()
+	structType := internal.StructType(elemType)
+	if structType == nil {
+		return fmt.Errorf("%w: %T", sqlg.ErrUnsupportedTarget, dest)
+	}
+
+	indexes, err := columnIndexes(rows, structType)
+	if err != nil {
+		return err
+	}
+
+	result := reflect.MakeSlice(slice.Type(), 0, 0)
+	for rows.Next() {
+		elem := reflect.New(structType)
+		if err := scanStruct(rows, elem.Elem(), indexes); err != nil {
+			return err
+		}
+
+		if elemType.Kind() != reflect.Ptr {
+			elem = elem.Elem()
+		}
+
+		result = reflect.Append(result, elem)
+	}
+
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	slice.Set(result)
+	return nil
+}
+
+// ScanOne scan the first row into dest by the tag `db`, dest must be the
+// pointer to structure, sql.ErrNoRows is returned when there is no row
+func ScanOne(rows *sql.Rows, dest interface{}) error {
+	value := reflect.ValueOf(dest)
+	if value.Kind() != reflect.Ptr || value.IsNil() || value.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("%w: %T", sqlg.ErrUnsupportedTarget, dest)
+	}
+
+	indexes, err := columnIndexes(rows, value.Elem().Type())
+	if err != nil {
+		return err
+	}
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return err
+		}
+
+		return sql.ErrNoRows
+	}
+
+	if err := scanStruct(rows, value.Elem(), indexes); err != nil {
+		return err
+	}
+
+	return rows.Close()
+}
+
+// columnIndexes return the field index of each column, the column is mapped
+// by name, and the column of expression is mapped by its alias
+func columnIndexes(rows *sql.Rows, t reflect.Type) ([][]int, error) {
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	info := internal.GetStructInfo(t)
+	indexes := make([][]int, 0, len(columns))
+	for _, column := range columns {
+		f, ok := info.Field(column)
+		if !ok {
+			return nil, fmt.Errorf("%w: %q of %s", ErrUnmappedColumn, column, t)
+		}
+
+		indexes = append(indexes, f.Index)
+	}
+
+	return indexes, nil
+}
+
+func scanStruct(rows *sql.Rows, v reflect.Value, indexes [][]int) error {
+	dest := make([]interface{}, 0, len(indexes))
+	for _, index := range indexes {
+		field, ok := fieldByIndex(v, index)
+		if !ok {
+			return fmt.Errorf("%w: nil embedded pointer of %s can not be allocated", ErrUnmappedColumn, v.Type())
+		}
+
+		dest = append(dest, field.Addr().Interface())
+	}
+
+	return rows.Scan(dest...)
+}
+
+// fieldByIndex return the nested field of structure, the nil embedded
+// pointer is allocated, false is returned when it's unexported
+func fieldByIndex(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				if !v.CanSet() {
+					return reflect.Value{}, false
+				}
+
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+
+			v = v.Elem()
+		}
+
+		v = v.Field(x)
+	}
+
+	return v, true
+}
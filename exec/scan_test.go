@@ -0,0 +1,201 @@
+package exec
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/wwwangxc/sqlg"
+)
+
+type Base struct {
+	ID int64 `db:"id,autoincr"`
+}
+
+type member struct {
+	*Base
+	Name     string         `db:"name"`
+	Nickname sql.NullString `db:"nickname"`
+	Age      *int64         `db:"age"`
+}
+
+func init() {
+	fakeTables["member"] = &fakeRows{
+		columns: []string{"id", "name", "nickname", "age"},
+		values:  [][]driver.Value{{int64(1), "tom", nil, nil}, {int64(2), "jerry", "mouse", int64(3)}},
+	}
+
+	fakeTables["order"] = &fakeRows{
+		columns: []string{"user_id", "total"},
+		values:  [][]driver.Value{{int64(1), int64(3)}},
+	}
+
+	fakeTables["hidden"] = &fakeRows{
+		columns: []string{"id", "name"},
+		values:  [][]driver.Value{{int64(1), "tom"}},
+	}
+
+	fakeTables["account"] = &fakeRows{
+		columns: []string{"id", "secret"},
+		values:  [][]driver.Value{{int64(1), "secret"}},
+	}
+}
+
+func TestSelectInto(t *testing.T) {
+	ctx := context.Background()
+	db := openDB(t)
+
+	age := int64(3)
+	want := []member{
+		{Base: &Base{ID: 1}, Name: "tom"},
+		{Base: &Base{ID: 2}, Name: "jerry", Nickname: sql.NullString{String: "mouse", Valid: true}, Age: &age},
+	}
+
+	var members []member
+	g := sqlg.NewGenerator("member", sqlg.WithAnd("id", sqlg.GT(0)))
+	if err := SelectInto(ctx, db, g, &members); err != nil {
+		t.Fatalf("SelectInto() error = %v", err)
+	}
+	assertExecuted(t, "SELECT `id`, `name`, `nickname`, `age` FROM `member` WHERE `id`>?", []driver.Value{int64(0)})
+
+	if !reflect.DeepEqual(members, want) {
+		t.Errorf("SelectInto() got = %+v, want %+v", members, want)
+	}
+
+	// slice of pointer
+	var pointers []*member
+	if err := SelectInto(ctx, db, g, &pointers); err != nil {
+		t.Fatalf("SelectInto() error = %v", err)
+	}
+
+	if len(pointers) != 2 || !reflect.DeepEqual(*pointers[1], want[1]) {
+		t.Errorf("SelectInto() got = %+v, want %+v", pointers, want)
+	}
+
+	// the expression column is mapped by its alias
+	type stat struct {
+		UserID int64 `db:"user_id"`
+		Total  int64 `db:"total" expr:"COUNT(*)"`
+	}
+
+	var stats []stat
+	if err := SelectInto(ctx, db, sqlg.NewGenerator("order", sqlg.WithGroupBy("user_id")), &stats); err != nil {
+		t.Fatalf("SelectInto() error = %v", err)
+	}
+	assertExecuted(t, "SELECT `user_id`, COUNT(*) AS `total` FROM `order` GROUP BY `user_id`", []driver.Value{})
+
+	if !reflect.DeepEqual(stats, []stat{{UserID: 1, Total: 3}}) {
+		t.Errorf("SelectInto() got = %+v", stats)
+	}
+
+	// the unexported field is skipped
+	type visible struct {
+		ID   int64  `db:"id"`
+		Name string `db:"name"`
+		note string `db:"note"`
+	}
+
+	var visibles []visible
+	if err := SelectInto(ctx, db, sqlg.NewGenerator("hidden"), &visibles); err != nil {
+		t.Fatalf("SelectInto() error = %v", err)
+	}
+	assertExecuted(t, "SELECT `id`, `name` FROM `hidden`", []driver.Value{})
+
+	if !reflect.DeepEqual(visibles, []visible{{ID: 1, Name: "tom"}}) {
+		t.Errorf("SelectInto() got = %+v", visibles)
+	}
+
+	// empty
+	members = []member{{Name: "stale"}}
+	if err := SelectInto(ctx, db, sqlg.NewGenerator("empty"), &members); err != nil || members == nil || len(members) != 0 {
+		t.Errorf("SelectInto() got = %+v, error = %v", members, err)
+	}
+}
+
+func TestSelectInto_Error(t *testing.T) {
+	ctx := context.Background()
+	db := openDB(t)
+
+	// unmapped column
+	type account struct {
+		ID int64 `db:"id"`
+	}
+
+	var accounts []account
+	err := scanAllFrom(ctx, db, "SELECT `id`, `secret` FROM `account`", &accounts)
+	if !errors.Is(err, ErrUnmappedColumn) || !errors.As(err, new(*Error)) {
+		t.Errorf("ScanAll() error = %v, want %v", err, ErrUnmappedColumn)
+	}
+
+	// the unexported embedded pointer can not be allocated
+	type base struct {
+		ID int64 `db:"id"`
+	}
+
+	type hidden struct {
+		*base
+		Name string `db:"name"`
+	}
+
+	var hiddens []hidden
+	err = SelectInto(ctx, db, sqlg.NewGenerator("hidden"), &hiddens)
+	if !errors.Is(err, ErrUnmappedColumn) {
+		t.Errorf("SelectInto() error = %v, want %v", err, ErrUnmappedColumn)
+	}
+
+	// unsupported target
+	err = SelectInto(ctx, db, sqlg.NewGenerator("member"), &member{})
+	if !errors.Is(err, sqlg.ErrUnsupportedTarget) {
+		t.Errorf("SelectInto() error = %v, want %v", err, sqlg.ErrUnsupportedTarget)
+	}
+
+	err = SelectInto(ctx, db, sqlg.NewGenerator("member"), []member{})
+	if !errors.Is(err, sqlg.ErrUnsupportedTarget) {
+		t.Errorf("SelectInto() error = %v, want %v", err, sqlg.ErrUnsupportedTarget)
+	}
+
+	// the error of executing
+	err = SelectInto(ctx, db, sqlg.NewGenerator("fail"), &accounts)
+	if !errors.As(err, new(*Error)) {
+		t.Errorf("SelectInto() error = %v", err)
+	}
+}
+
+func TestGetInto(t *testing.T) {
+	ctx := context.Background()
+	db := openDB(t)
+
+	var m member
+	if err := GetInto(ctx, db, sqlg.NewGenerator("member", sqlg.WithAnd("id", sqlg.EQ(1))), &m); err != nil {
+		t.Fatalf("GetInto() error = %v", err)
+	}
+	assertExecuted(t, "SELECT `id`, `name`, `nickname`, `age` FROM `member` WHERE `id`=? LIMIT 1", []driver.Value{int64(1)})
+
+	if !reflect.DeepEqual(m, member{Base: &Base{ID: 1}, Name: "tom"}) {
+		t.Errorf("GetInto() got = %+v", m)
+	}
+
+	err := GetInto(ctx, db, sqlg.NewGenerator("empty"), &m)
+	if !errors.Is(err, sql.ErrNoRows) || !errors.As(err, new(*Error)) {
+		t.Errorf("GetInto() error = %v, want %v", err, sql.ErrNoRows)
+	}
+
+	err = GetInto(ctx, db, sqlg.NewGenerator("member"), m)
+	if !errors.Is(err, sqlg.ErrUnsupportedTarget) {
+		t.Errorf("GetInto() error = %v, want %v", err, sqlg.ErrUnsupportedTarget)
+	}
+}
+
+// scanAllFrom query the statement and scan the rows
+func scanAllFrom(ctx context.Context, db Executor, query string, dest interface{}) error {
+	rows, err := QueryContext(ctx, db, NewStatement(query, nil, nil))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	return wrapError(query, ScanAll(rows, dest))
+}
@@ -5,131 +5,279 @@ import (
 	"github.com/wwwangxc/sqlg/internal/expr"
 )
 
-// Expr expression
-type Expr func(op internal.Operator, column string) internal.Expression
+// Expr expression, use NewExpr to create Expr with the user implemented
+// Expression
+//
+// The column is treated as the condition when the expr is nil, such as the
+// trusted expression of Raw.
+//
+// EXP:
+//
+//	WithAnd(Raw("JSON_CONTAINS(`tags`, ?)", tag), nil)
+type Expr interface {
+	expression(op internal.Operator, column interface{}) internal.Expression
+}
+
+// exprFunc adapt the function into Expr
+type exprFunc func(op internal.Operator, column interface{}) internal.Expression
+
+func (f exprFunc) expression(op internal.Operator, column interface{}) internal.Expression {
+	return f(op, column)
+}
+
+// buildExpr return the expression of column, the column is treated as the
+// condition when the expr is nil
+func buildExpr(e Expr, op internal.Operator, column interface{}) internal.Expression {
+	if e == nil {
+		return expr.NewRaw(op, column)
+	}
+
+	return e.expression(op, column)
+}
 
 // EQ equal expression
 func EQ(value interface{}) Expr {
-	return func(op internal.Operator, column string) internal.Expression {
+	return exprFunc(func(op internal.Operator, column interface{}) internal.Expression {
 		return expr.NewEQ(op, column, value)
-	}
+	})
+}
+
+// EQColumn equal to another column expression
+//
+// EXP:
+//
+//	${column}=${other}
+func EQColumn(other string) Expr {
+	return exprFunc(func(op internal.Operator, column interface{}) internal.Expression {
+		return expr.NewColumnEQ(op, column, other)
+	})
 }
 
 // NEQ not equal expression
 func NEQ(value interface{}) Expr {
-	return func(op internal.Operator, column string) internal.Expression {
+	return exprFunc(func(op internal.Operator, column interface{}) internal.Expression {
 		return expr.NewNEQ(op, column, value)
-	}
+	})
 }
 
 // GT greater than expression
 func GT(value interface{}) Expr {
-	return func(op internal.Operator, column string) internal.Expression {
+	return exprFunc(func(op internal.Operator, column interface{}) internal.Expression {
 		return expr.NewGT(op, column, value)
-	}
+	})
 }
 
 // GTE greater than or equal expression
 func GTE(value interface{}) Expr {
-	return func(op internal.Operator, column string) internal.Expression {
+	return exprFunc(func(op internal.Operator, column interface{}) internal.Expression {
 		return expr.NewGTE(op, column, value)
-	}
+	})
 }
 
 // LT less than expression
 func LT(value interface{}) Expr {
-	return func(op internal.Operator, column string) internal.Expression {
+	return exprFunc(func(op internal.Operator, column interface{}) internal.Expression {
 		return expr.NewLT(op, column, value)
-	}
+	})
 }
 
 // LTE less than or equal expression
 func LTE(value interface{}) Expr {
-	return func(op internal.Operator, column string) internal.Expression {
+	return exprFunc(func(op internal.Operator, column interface{}) internal.Expression {
 		return expr.NewLTE(op, column, value)
-	}
+	})
 }
 
 // In expression
 func In(values []interface{}) Expr {
-	return func(op internal.Operator, column string) internal.Expression {
+	return exprFunc(func(op internal.Operator, column interface{}) internal.Expression {
 		return expr.NewIn(op, column, values)
-	}
+	})
 }
 
 // NIn not in expression
 func NIn(values []interface{}) Expr {
-	return func(op internal.Operator, column string) internal.Expression {
+	return exprFunc(func(op internal.Operator, column interface{}) internal.Expression {
 		return expr.NewNIn(op, column, values)
-	}
+	})
 }
 
 // Between expression
 func Between(value1, value2 interface{}) Expr {
-	return func(op internal.Operator, column string) internal.Expression {
+	return exprFunc(func(op internal.Operator, column interface{}) internal.Expression {
 		return expr.NewBetween(op, column, value1, value2)
-	}
+	})
 }
 
 // NBetween not between expression
 func NBetween(value1, value2 interface{}) Expr {
-	return func(op internal.Operator, column string) internal.Expression {
+	return exprFunc(func(op internal.Operator, column interface{}) internal.Expression {
 		return expr.NewNBetween(op, column, value1, value2)
-	}
+	})
 }
 
 // Like expression
 func Like(value interface{}) Expr {
-	return func(op internal.Operator, column string) internal.Expression {
+	return exprFunc(func(op internal.Operator, column interface{}) internal.Expression {
 		return expr.NewLike(op, column, "%%%s%%", value)
-	}
+	})
 }
 
 // NLike not like expression
 func NLike(value interface{}) Expr {
-	return func(op internal.Operator, column string) internal.Expression {
+	return exprFunc(func(op internal.Operator, column interface{}) internal.Expression {
 		return expr.NewNLike(op, column, "%%%s%%", value)
-	}
+	})
 }
 
 // LikePrefix expression
 func LikePrefix(value interface{}) Expr {
-	return func(op internal.Operator, column string) internal.Expression {
+	return exprFunc(func(op internal.Operator, column interface{}) internal.Expression {
 		return expr.NewLike(op, column, "%s%%", value)
-	}
+	})
 }
 
 // NLikePrefix not like prefix expression
 func NLikePrefix(value interface{}) Expr {
-	return func(op internal.Operator, column string) internal.Expression {
+	return exprFunc(func(op internal.Operator, column interface{}) internal.Expression {
 		return expr.NewNLike(op, column, "%s%%", value)
-	}
+	})
 }
 
 // LikeSuffix expression
 func LikeSuffix(value interface{}) Expr {
-	return func(op internal.Operator, column string) internal.Expression {
+	return exprFunc(func(op internal.Operator, column interface{}) internal.Expression {
 		return expr.NewLike(op, column, "%%%s", value)
-	}
+	})
 }
 
 // NLikeSuffix not like suffix expression
 func NLikeSuffix(value interface{}) Expr {
-	return func(op internal.Operator, column string) internal.Expression {
+	return exprFunc(func(op internal.Operator, column interface{}) internal.Expression {
 		return expr.NewNLike(op, column, "%%%s", value)
-	}
+	})
 }
 
 // Null expression
 func Null() Expr {
-	return func(op internal.Operator, column string) internal.Expression {
+	return exprFunc(func(op internal.Operator, column interface{}) internal.Expression {
 		return expr.NewNull(op, column)
-	}
+	})
 }
 
 // NNull not null expression
 func NNull() Expr {
-	return func(op internal.Operator, column string) internal.Expression {
+	return exprFunc(func(op internal.Operator, column interface{}) internal.Expression {
 		return expr.NewNNull(op, column)
-	}
+	})
+}
+
+// EQQuery equal to the result of subquery expression
+//
+// EXP:
+//
+//	${column}=(${subquery})
+func EQQuery(g *Generator, columns ...interface{}) Expr {
+	return exprFunc(func(op internal.Operator, column interface{}) internal.Expression {
+		return expr.NewSubquery(op, column, "=", newSubquery(g, columns))
+	})
+}
+
+// NEQQuery not equal to the result of subquery expression
+//
+// EXP:
+//
+//	${column}!=(${subquery})
+func NEQQuery(g *Generator, columns ...interface{}) Expr {
+	return exprFunc(func(op internal.Operator, column interface{}) internal.Expression {
+		return expr.NewSubquery(op, column, "!=", newSubquery(g, columns))
+	})
+}
+
+// GTQuery greater than the result of subquery expression
+//
+// EXP:
+//
+//	${column}>(${subquery})
+func GTQuery(g *Generator, columns ...interface{}) Expr {
+	return exprFunc(func(op internal.Operator, column interface{}) internal.Expression {
+		return expr.NewSubquery(op, column, ">", newSubquery(g, columns))
+	})
+}
+
+// GTEQuery greater than or equal to the result of subquery expression
+//
+// EXP:
+//
+//	${column}>=(${subquery})
+func GTEQuery(g *Generator, columns ...interface{}) Expr {
+	return exprFunc(func(op internal.Operator, column interface{}) internal.Expression {
+		return expr.NewSubquery(op, column, ">=", newSubquery(g, columns))
+	})
+}
+
+// LTQuery less than the result of subquery expression
+//
+// EXP:
+//
+//	${column}<(${subquery})
+func LTQuery(g *Generator, columns ...interface{}) Expr {
+	return exprFunc(func(op internal.Operator, column interface{}) internal.Expression {
+		return expr.NewSubquery(op, column, "<", newSubquery(g, columns))
+	})
+}
+
+// LTEQuery less than or equal to the result of subquery expression
+//
+// EXP:
+//
+//	${column}<=(${subquery})
+func LTEQuery(g *Generator, columns ...interface{}) Expr {
+	return exprFunc(func(op internal.Operator, column interface{}) internal.Expression {
+		return expr.NewSubquery(op, column, "<=", newSubquery(g, columns))
+	})
+}
+
+// InQuery in the result of subquery expression
+//
+// EXP:
+//
+//	${column} IN (${subquery})
+func InQuery(g *Generator, columns ...interface{}) Expr {
+	return exprFunc(func(op internal.Operator, column interface{}) internal.Expression {
+		return expr.NewSubquery(op, column, " IN ", newSubquery(g, columns))
+	})
+}
+
+// NInQuery not in the result of subquery expression
+//
+// EXP:
+//
+//	${column} NOT IN (${subquery})
+func NInQuery(g *Generator, columns ...interface{}) Expr {
+	return exprFunc(func(op internal.Operator, column interface{}) internal.Expression {
+		return expr.NewSubquery(op, column, " NOT IN ", newSubquery(g, columns))
+	})
+}
+
+// ExistsQuery exists expression, the column of expression is ignored
+//
+// EXP:
+//
+//	EXISTS (${subquery})
+func ExistsQuery(g *Generator) Expr {
+	return exprFunc(func(op internal.Operator, column interface{}) internal.Expression {
+		return expr.NewSubquery(op, "", "EXISTS ", newSubquery(g, nil))
+	})
+}
+
+// NExistsQuery not exists expression, the column of expression is ignored
+//
+// EXP:
+//
+//	NOT EXISTS (${subquery})
+func NExistsQuery(g *Generator) Expr {
+	return exprFunc(func(op internal.Operator, column interface{}) internal.Expression {
+		return expr.NewSubquery(op, "", "NOT EXISTS ", newSubquery(g, nil))
+	})
 }
@@ -0,0 +1,84 @@
+package sqlg
+
+import (
+	"fmt"
+
+	"github.com/wwwangxc/sqlg/internal"
+)
+
+// Expression of condition which can be implemented by user, use NewExpr to
+// convert it into Expr
+//
+// The expression can implement Validate() error, which will be called by the
+// Build methods.
+type Expression interface {
+	// ToSQL return the predicate of column and the params of ? placeholders
+	//
+	// The column is quoted with the dialect of statement, the backtick quoted
+	// identifiers of predicate will be requoted with the dialect. The
+	// predicate is parenthesized and joined with the previous one by
+	// generator.
+	ToSQL(column string) (string, []interface{})
+}
+
+// ExprFunc adapt the function into Expression
+type ExprFunc func(column string) (string, []interface{})
+
+// ToSQL return the predicate of column and the params of ? placeholders
+func (f ExprFunc) ToSQL(column string) (string, []interface{}) {
+	return f(column)
+}
+
+// NewExpr create Expr with the user implemented expression
+//
+// EXP:
+//
+//	NewExpr(ExprFunc(func(column string) (string, []interface{}) {
+//		return fmt.Sprintf("%s&?=?", column), []interface{}{mask, mask}
+//	}))
+func NewExpr(e Expression) Expr {
+	return exprFunc(func(op internal.Operator, column interface{}) internal.Expression {
+		return &expression{
+			op:     op,
+			column: column,
+			e:      e,
+		}
+	})
+}
+
+var (
+	_ internal.Expression = (*expression)(nil)
+	_ internal.Validator  = (*expression)(nil)
+)
+
+// expression adapt the user implemented expression into internal expression
+type expression struct {
+	op     internal.Operator
+	column interface{}
+	e      Expression
+}
+
+func (e *expression) ToSQL(d internal.Dialect) (string, []interface{}) {
+	if e == nil || e.e == nil {
+		return "", nil
+	}
+
+	sql, params := e.e.ToSQL(internal.SafeName(d, e.column))
+	return fmt.Sprintf("%s (%s)", e.op, internal.Requote(d, sql)), params
+}
+
+func (e *expression) Validate(d internal.Dialect) error {
+	if e == nil {
+		return nil
+	}
+
+	if err := internal.ValidateName(d, e.column); err != nil {
+		return err
+	}
+
+	if v, ok := e.e.(interface{ Validate() error }); ok {
+		return v.Validate()
+	}
+
+	return nil
+}
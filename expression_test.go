@@ -0,0 +1,72 @@
+package sqlg
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// bitmask check the bits of column
+type bitmask uint32
+
+func (b bitmask) ToSQL(column string) (string, []interface{}) {
+	return fmt.Sprintf("%s&?=?", column), []interface{}{uint32(b), uint32(b)}
+}
+
+func (b bitmask) Validate() error {
+	if b == 0 {
+		return errors.New("empty bitmask")
+	}
+
+	return nil
+}
+
+func TestNewExpr(t *testing.T) {
+	distance := NewExpr(ExprFunc(func(column string) (string, []interface{}) {
+		return fmt.Sprintf("ST_Distance_Sphere(%s, POINT(?, ?))<=?", column), []interface{}{121.47, 31.23, 1000}
+	}))
+
+	exprs := NewCompExpr()
+	exprs.Put("flags", NewExpr(bitmask(4)))
+	exprs.Put("location", distance)
+	ops := []Option{
+		WithAnd("status", EQ(1)),
+		WithAnd("flags", NewExpr(bitmask(3))),
+		WithOrExprs(exprs),
+	}
+
+	g := NewGenerator("shop", ops...)
+	gotSQL, gotParams := g.Select("id")
+	wantSQL := "SELECT `id` FROM `shop` WHERE `status`=? AND (`flags`&?=?) " +
+		"OR ((`flags`&?=?) AND (ST_Distance_Sphere(`location`, POINT(?, ?))<=?))"
+	assertSQL(t, gotSQL, wantSQL)
+	assertParams(t, gotParams, []interface{}{1, uint32(3), uint32(3), uint32(4), uint32(4), 121.47, 31.23, 1000})
+
+	// quoted with the dialect
+	g = NewGenerator("shop", append(ops, WithDialect(Postgres))...)
+	gotSQL, _ = g.Select("id")
+	wantSQL = `SELECT "id" FROM "shop" WHERE "status"=$1 AND ("flags"&$2=$3) ` +
+		`OR (("flags"&$4=$5) AND (ST_Distance_Sphere("location", POINT($6, $7))<=$8))`
+	assertSQL(t, gotSQL, wantSQL)
+
+	// the first expression
+	gotSQL, _ = NewGenerator("shop", WithOr("flags", NewExpr(bitmask(1)))).Select("id")
+	assertSQL(t, gotSQL, "SELECT `id` FROM `shop` WHERE (`flags`&?=?)")
+
+	// the predicate is parenthesized to keep the precedence
+	nullOrZero := NewExpr(ExprFunc(func(column string) (string, []interface{}) {
+		return fmt.Sprintf("%s IS NULL OR %s=?", column, column), []interface{}{0}
+	}))
+	gotSQL, gotParams = NewGenerator("shop", WithAnd("status", EQ(1)), WithAnd("score", nullOrZero)).Select("id")
+	assertSQL(t, gotSQL, "SELECT `id` FROM `shop` WHERE `status`=? AND (`score` IS NULL OR `score`=?)")
+	assertParams(t, gotParams, []interface{}{1, 0})
+
+	// validated by the Build methods
+	_, _, err := NewGenerator("shop", WithAnd("flags", NewExpr(bitmask(0)))).BuildSelect("id")
+	assertError(t, err, errors.New("empty bitmask"))
+
+	_, _, err = NewGenerator("shop", WithAnd("flags;", NewExpr(bitmask(1)))).BuildSelect("id")
+	if !errors.Is(err, ErrUnsafeIdentifier) {
+		t.Errorf("got error dose not meet the expected\nexpected: %v\n  actual: %v", ErrUnsafeIdentifier, err)
+	}
+}
@@ -0,0 +1,54 @@
+package sqlg
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Func function call expression, the name is written without quoting, the
+// args can be column reference, trusted expression or value which is bound
+// as param
+//
+// EXP:
+//
+//	Func("NOW")
+//	Func("COALESCE", Col("nickname"), "anonymous")
+//	${name}(${arg1}, ${arg2})
+func Func(name string, args ...interface{}) RawExpr {
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(args)), ", ")
+	return Raw(fmt.Sprintf("%s(%s)", name, placeholders), args...)
+}
+
+// Incr increase the column, can be used as value of assignment
+//
+// EXP:
+//
+//	Incr("views", 1)
+//	${column} + ?
+func Incr(column string, n interface{}) RawExpr {
+	return Add(Col(column), n)
+}
+
+// Decr decrease the column, can be used as value of assignment
+//
+// EXP:
+//
+//	Decr("stock", 1)
+//	${column} - ?
+func Decr(column string, n interface{}) RawExpr {
+	return Sub(Col(column), n)
+}
+
+// Values reference the value proposed for insertion of column, can be used
+// as value of assignment of ON DUPLICATE KEY UPDATE clause, supported by
+// MySQL only, the BuildInsert of ON CONFLICT dialects return
+// ErrUnsupportedClause
+//
+// EXP:
+//
+//	VALUES(${column})
+func Values(column string) RawExpr {
+	r := Func("VALUES", Col(column))
+	r.values = true
+	return r
+}
@@ -0,0 +1,119 @@
+package sqlg
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestFunc(t *testing.T) {
+	tests := []struct {
+		name       string
+		expr       RawExpr
+		wantSQL    string
+		wantParams []interface{}
+	}{
+		{
+			name:    "without args",
+			expr:    Func("NOW"),
+			wantSQL: "NOW()",
+		},
+		{
+			name:       "args",
+			expr:       Func("COALESCE", Col("nickname"), "anonymous"),
+			wantSQL:    "COALESCE(`nickname`, ?)",
+			wantParams: []interface{}{"anonymous"},
+		},
+		{
+			name:       "nested",
+			expr:       Func("GREATEST", Sub(Col("stock"), 3), 0),
+			wantSQL:    "GREATEST(`stock` - ?, ?)",
+			wantParams: []interface{}{3, 0},
+		},
+		{
+			name:       "incr",
+			expr:       Incr("views", 1),
+			wantSQL:    "`views` + ?",
+			wantParams: []interface{}{1},
+		},
+		{
+			name:       "decr",
+			expr:       Decr("t.stock", 2),
+			wantSQL:    "`t`.`stock` - ?",
+			wantParams: []interface{}{2},
+		},
+		{
+			name:    "values",
+			expr:    Values("name"),
+			wantSQL: "VALUES(`name`)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotSQL, gotParams := tt.expr.RawSQL()
+			assertSQL(t, gotSQL, tt.wantSQL)
+			assertParams(t, gotParams, tt.wantParams)
+		})
+	}
+}
+
+func TestGenerator_UpdateFunc(t *testing.T) {
+	assExpr := NewAssExpr()
+	assExpr.Put("title", "hello")
+	assExpr.Put("views", Incr("views", 1))
+	assExpr.Put("stock", Func("GREATEST", Decr("stock", 3), 0))
+	assExpr.Put("updated_at", Func("NOW"))
+	assExpr.Put("nickname", Func("COALESCE", Col("nickname"), "anonymous"))
+
+	g := NewGenerator("article", WithAnd("id", EQ(1)))
+	gotSQL, gotParams := g.Update(assExpr)
+	wantSQL := "UPDATE `article` SET `title`=?, `views`=`views` + ?, `stock`=GREATEST(`stock` - ?, ?), " +
+		"`updated_at`=NOW(), `nickname`=COALESCE(`nickname`, ?) WHERE `id`=?"
+	assertSQL(t, gotSQL, wantSQL)
+	assertParams(t, gotParams, []interface{}{"hello", 1, 3, 0, "anonymous", 1})
+
+	// quoted with the dialect
+	gotSQL, gotParams = NewGenerator("article", WithAnd("id", EQ(1)), WithDialect(Postgres)).Update(assExpr)
+	wantSQL = `UPDATE "article" SET "title"=$1, "views"="views" + $2, "stock"=GREATEST("stock" - $3, $4), ` +
+		`"updated_at"=NOW(), "nickname"=COALESCE("nickname", $5) WHERE "id"=$6`
+	assertSQL(t, gotSQL, wantSQL)
+	assertParams(t, gotParams, []interface{}{"hello", 1, 3, 0, "anonymous", 1})
+
+	// upsert
+	assExpr = NewAssExpr()
+	assExpr.Put("name", Values("name"))
+	assExpr.Put("views", Incr("views", 1))
+	assExpr.Put("updated_at", Func("NOW"))
+	g = NewGenerator("article", OnDuplicateKeyUpdate(assExpr))
+	gotSQL, gotParams = g.Insert([]string{"id", "name"}, []interface{}{1, "tom"})
+	wantSQL = "INSERT INTO `article` (`id`, `name`) VALUES (?,?) " +
+		"ON DUPLICATE KEY UPDATE `name`=VALUES(`name`), `views`=`views` + ?, `updated_at`=NOW()"
+	assertSQL(t, gotSQL, wantSQL)
+	assertParams(t, gotParams, []interface{}{1, "tom", 1})
+
+	// VALUES function is not supported by ON CONFLICT clause
+	for _, d := range []Dialect{Postgres, SQLite} {
+		_, _, err := g.With(WithDialect(d), OnConflict("id")).BuildInsert([]string{"id", "name"}, []interface{}{1, "tom"})
+		if !errors.Is(err, ErrUnsupportedClause) {
+			t.Errorf("got error dose not meet the expected\nexpected: %v\n  actual: %v", ErrUnsupportedClause, err)
+		}
+	}
+
+	// nested VALUES function
+	assExpr = NewAssExpr()
+	assExpr.Put("views", Add(Col("views"), Values("views")))
+	g = NewGenerator("article", OnDuplicateKeyUpdate(assExpr), OnConflict("id"), WithDialect(Postgres))
+	_, _, err := g.BuildInsert([]string{"id", "views"}, []interface{}{1, 1})
+	if !errors.Is(err, ErrUnsupportedClause) {
+		t.Errorf("got error dose not meet the expected\nexpected: %v\n  actual: %v", ErrUnsupportedClause, err)
+	}
+
+	// assigned by OnConflictUpdate
+	g = NewGenerator("article", OnConflict("id"), OnConflictUpdate("views"), WithDialect(Postgres))
+	gotSQL, gotParams, err = g.BuildInsert([]string{"id", "views"}, []interface{}{1, 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSQL(t, gotSQL, `INSERT INTO "article" ("id", "views") VALUES ($1,$2) ON CONFLICT ("id") DO UPDATE SET "views"=excluded."views"`)
+	assertParams(t, gotParams, []interface{}{1, 1})
+}
@@ -14,109 +14,354 @@ var allColumns = []string{"*"}
 
 // Generator of SQL statement
 type Generator struct {
-	table string
-	opts  *Options
+	table   string
+	from    internal.Query
+	setOp   *setOperation
+	options []Option
+	opts    *Options
 }
 
 // NewGenerator create generator
 func NewGenerator(table string, opts ...Option) *Generator {
 	return &Generator{
-		table: table,
-		opts:  newOptions(opts...),
+		table:   table,
+		options: opts,
+		opts:    newOptions(opts...),
+	}
+}
+
+// NewDerivedGenerator create generator which select from the derived table
+//
+// The derived table is the select statement of the query generator with
+// columns, all columns will be selected when columns is empty. The alias is
+// required, and the generator can only generate select statement.
+//
+// EXP:
+//
+//	SELECT * FROM (${query}) AS ${alias}
+func NewDerivedGenerator(query *Generator, columns []interface{}, alias string, opts ...Option) *Generator {
+	return &Generator{
+		table:   alias,
+		from:    newSubquery(query, columns),
+		options: opts,
+		opts:    newOptions(opts...),
+	}
+}
+
+// With return the copy of generator with the options appended
+func (g *Generator) With(opts ...Option) *Generator {
+	if g == nil {
+		return nil
+	}
+
+	options := make([]Option, 0, len(g.options)+len(opts))
+	options = append(options, g.options...)
+	options = append(options, opts...)
+	return &Generator{
+		table:   g.table,
+		from:    g.from,
+		setOp:   g.setOp,
+		options: options,
+		opts:    newOptions(options...),
 	}
 }
 
 // Select return select statement and params
-func (g *Generator) Select(columns ...string) (string, []interface{}) {
+func (g *Generator) Select(columns ...interface{}) (string, []interface{}) {
 	if g == nil {
 		return "", nil
 	}
 
+	sql, params := g.genSelect(columns...)
+	return g.rebind(sql, params)
+}
+
+// BuildSelect return select statement and params, the error is returned
+// when the statement is invalid
+func (g *Generator) BuildSelect(columns ...interface{}) (string, []interface{}, error) {
+	if err := g.validateSelect(columns); err != nil {
+		return "", nil, err
+	}
+
+	sql, params := g.Select(columns...)
+	return sql, params, nil
+}
+
+func (g *Generator) genSelect(columns ...interface{}) (string, []interface{}) {
+	if g.setOp != nil {
+		return g.genSetOperation()
+	}
+
+	if len(columns) == 0 {
+		columns = g.opts.columns
+	}
+
 	if len(columns) == 0 {
-		columns = allColumns
+		columns = []interface{}{"*"}
 	}
 
-	where, params := g.opts.genWhere()
-	sql := bytes.NewBufferString("SELECT")
-	fmt.Fprintf(sql, " %s", strings.Join(internal.SafeNames(columns), ", "))
-	fmt.Fprintf(sql, " FROM %s", internal.SafeName(g.table))
-	sql.WriteString(sqlOrEmpty(g.opts.genForceIndex()))
+	with, params := g.opts.genWith()
+	names, columnParams := internal.SafeNamesWithArgs(g.opts.dialect, columns)
+	from, fromParams := g.genFrom()
+	joins, joinParams := g.opts.genJoins()
+	where, whereParams := g.opts.genSelectWhere()
+	groupBy, groupByParams := g.opts.genGroupBy()
+	having, havingParams := g.opts.genHaving()
+	window, windowParams := g.opts.genWindow()
+	orderBy, orderByParams := g.opts.genOrderBy()
+	params = append(params, columnParams...)
+	params = append(params, fromParams...)
+	params = append(params, joinParams...)
+	params = append(params, whereParams...)
+	params = append(params, groupByParams...)
+	params = append(params, havingParams...)
+	params = append(params, windowParams...)
+	params = append(params, orderByParams...)
+
+	sql := bytes.NewBufferString(strings.TrimSpace(with + " SELECT"))
+	sql.WriteString(sqlOrEmpty(g.opts.genTop()))
+	fmt.Fprintf(sql, " %s", strings.Join(names, ", "))
+	fmt.Fprintf(sql, " FROM %s", from)
+	sql.WriteString(sqlOrEmpty(g.opts.genTableHints()))
+	sql.WriteString(sqlOrEmpty(joins))
 	sql.WriteString(sqlOrEmpty(where))
-	sql.WriteString(sqlOrEmpty(g.opts.genGroupBy()))
-	sql.WriteString(sqlOrEmpty(g.opts.genOrderBy()))
-	sql.WriteString(sqlOrEmpty(g.opts.genLimit()))
-	sql.WriteString(sqlOrEmpty(g.opts.genOffset()))
+	sql.WriteString(sqlOrEmpty(groupBy))
+	sql.WriteString(sqlOrEmpty(having))
+	sql.WriteString(sqlOrEmpty(window))
+	sql.WriteString(sqlOrEmpty(orderBy))
+	sql.WriteString(sqlOrEmpty(g.opts.genPaging(true)))
 	sql.WriteString(sqlOrEmpty(g.opts.genForUpdate()))
 
 	return sql.String(), params
 }
 
+// genFrom return the table or the derived table of select statement
+func (g *Generator) genFrom() (string, []interface{}) {
+	if g.from == nil {
+		return g.safeName(g.table) + sqlOrEmpty(g.opts.genAlias()), nil
+	}
+
+	sql, params := g.from.ToSQL(g.opts.dialect)
+	return fmt.Sprintf("(%s) AS %s", sql, g.safeName(g.table)), params
+}
+
 // SelectByStruct return select statement and params
 //
-// The column of the query is obtained from the tag `db` of the target structure
+// The column of the query is obtained from the tag `db` of the target, which
+// can be structure, pointer or slice of them. The fields of embedded
+// structure are flattened, and the fields of nested structure with the
+// prefix option are flattened with the columns prefixed.
 func (g *Generator) SelectByStruct(target interface{}) (string, []interface{}, error) {
 	if g == nil {
 		return "", nil, nil
 	}
 
-	columns, err := getColumns(target)
+	info, err := getTargetInfo(target)
 	if err != nil {
 		return "", nil, err
 	}
 
-	sql, params := g.Select(columns...)
+	sql, params := g.Select(info.SelectColumns(g.opts.dialect)...)
 	return sql, params, nil
 }
 
-// Update return update statement and params
+// Update return update statement and params, the empty statement is
+// returned when the clauses are not supported by the dialect
 func (g *Generator) Update(assExpr *AssExpr) (string, []interface{}) {
-	if g == nil || assExpr.empty() {
+	if g == nil || g.derived() || assExpr.empty() || g.opts.validateMutation() != nil {
 		return "", nil
 	}
 
-	set, params := g.opts.genSet(assExpr)
-	where, whereParams := g.opts.genWhere()
+	sql, params := g.opts.dialect.genUpdate(g, assExpr)
+	return g.rebind(sql, params)
+}
+
+// UpdateByStruct return update statement and params
+//
+// The assignments are obtained from the tag `db` of the target, which can be
+// structure or pointer. The autoincr and readonly columns are skipped, the
+// omitempty columns are skipped when they are zero value, and the value of
+// driver.Valuer field is converted. ErrEmptyAssignment is returned when there
+// is no column to be assigned, and the error is returned when the statement
+// is invalid as BuildUpdate.
+//
+// EXP:
+//
+//	g.UpdateByStruct(user, OmitColumns("created_by"))
+func (g *Generator) UpdateByStruct(target interface{}, opts ...StructOption) (string, []interface{}, error) {
+	if g == nil {
+		return "", nil, nil
+	}
+
+	assExpr, err := getAssignments(target, opts...)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return g.updateByAssignments(assExpr)
+}
+
+// UpdateDiff return update statement and params, only the columns whose
+// values are changed from old to new are assigned
+//
+// The old and new must be the same type of structure or pointer, the
+// autoincr and readonly columns are skipped. The time of same instant is not
+// changed, regardless of the location. ErrEmptyAssignment is returned when
+// nothing is changed, and the error is returned when the statement is invalid
+// as BuildUpdate.
+//
+// EXP:
+//
+//	g.UpdateDiff(old, new, OnlyColumns("name", "age"))
+func (g *Generator) UpdateDiff(oldTarget, newTarget interface{}, opts ...StructOption) (string, []interface{}, error) {
+	if g == nil {
+		return "", nil, nil
+	}
+
+	assExpr, err := getDiffAssignments(oldTarget, newTarget, opts...)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return g.updateByAssignments(assExpr)
+}
+
+func (g *Generator) updateByAssignments(assExpr *AssExpr) (string, []interface{}, error) {
+	if assExpr.empty() {
+		return "", nil, ErrEmptyAssignment
+	}
+
+	return g.BuildUpdate(assExpr)
+}
+
+// BuildUpdate return update statement and params, the error is returned
+// when the assignment expression is empty or the statement is invalid
+func (g *Generator) BuildUpdate(assExpr *AssExpr) (string, []interface{}, error) {
+	if err := g.validateUpdate(assExpr); err != nil {
+		return "", nil, err
+	}
+
+	sql, params := g.Update(assExpr)
+	return sql, params, nil
+}
+
+func (g *Generator) genUpdate(assExpr *AssExpr) (string, []interface{}) {
+	with, params := g.opts.genWith()
+	set, setParams := g.opts.genSet(assExpr)
+	where, whereParams := g.opts.genFoldedWhere()
+	orderBy, orderByParams := g.opts.genOrderBy()
+	params = append(params, setParams...)
 	params = append(params, whereParams...)
+	params = append(params, orderByParams...)
 
-	sql := bytes.NewBufferString(fmt.Sprintf("UPDATE %s", internal.SafeName(g.table)))
+	output, returning := g.opts.genReturning("inserted")
+	sql := bytes.NewBufferString(strings.TrimSpace(with + " UPDATE"))
+	sql.WriteString(sqlOrEmpty(g.opts.genTop()))
+	fmt.Fprintf(sql, " %s", g.safeName(g.table))
 	sql.WriteString(sqlOrEmpty(set))
+	sql.WriteString(sqlOrEmpty(output))
 	sql.WriteString(sqlOrEmpty(where))
-	sql.WriteString(sqlOrEmpty(g.opts.genOrderBy()))
-	sql.WriteString(sqlOrEmpty(g.opts.genLimit()))
-	sql.WriteString(sqlOrEmpty(g.opts.genOffset()))
+	sql.WriteString(sqlOrEmpty(orderBy))
+	sql.WriteString(sqlOrEmpty(g.opts.genPaging(true)))
+	sql.WriteString(sqlOrEmpty(returning))
 
 	return sql.String(), params
 }
 
-// Delete return delete statement and params
+// Delete return delete statement and params, the empty statement is
+// returned when the clauses are not supported by the dialect
 func (g *Generator) Delete() (string, []interface{}) {
-	if g == nil {
+	if g == nil || g.derived() || g.opts.validateMutation() != nil {
 		return "", nil
 	}
 
-	where, params := g.opts.genWhere()
-	sql := bytes.NewBufferString("DELETE")
-	fmt.Fprintf(sql, " FROM %s", internal.SafeName(g.table))
+	sql, params := g.opts.dialect.genDelete(g)
+	return g.rebind(sql, params)
+}
+
+// BuildDelete return delete statement and params, the error is returned
+// when the statement is invalid
+func (g *Generator) BuildDelete() (string, []interface{}, error) {
+	if err := g.validateDelete(); err != nil {
+		return "", nil, err
+	}
+
+	sql, params := g.Delete()
+	return sql, params, nil
+}
+
+func (g *Generator) genDelete() (string, []interface{}) {
+	with, params := g.opts.genWith()
+	where, whereParams := g.opts.genFoldedWhere()
+	orderBy, orderByParams := g.opts.genOrderBy()
+	params = append(params, whereParams...)
+	params = append(params, orderByParams...)
+
+	output, returning := g.opts.genReturning("deleted")
+	sql := bytes.NewBufferString(strings.TrimSpace(with + " DELETE"))
+	sql.WriteString(sqlOrEmpty(g.opts.genTop()))
+	fmt.Fprintf(sql, " FROM %s", g.safeName(g.table))
+	sql.WriteString(sqlOrEmpty(output))
 	sql.WriteString(sqlOrEmpty(where))
-	sql.WriteString(sqlOrEmpty(g.opts.genOrderBy()))
-	sql.WriteString(sqlOrEmpty(g.opts.genLimit()))
-	sql.WriteString(sqlOrEmpty(g.opts.genOffset()))
+	sql.WriteString(sqlOrEmpty(orderBy))
+	sql.WriteString(sqlOrEmpty(g.opts.genPaging(true)))
+	sql.WriteString(sqlOrEmpty(returning))
 
 	return sql.String(), params
 }
 
-// Insert return insert statement and params
+// InsertByStruct return insert statement and params
+//
+// The columns and records are obtained from the tag `db` of the target,
+// which can be structure, pointer or slice of them. The readonly columns are
+// skipped, the autoincr and omitempty columns are skipped when they are zero
+// value in all the records, and the value of driver.Valuer field is
+// converted. The error is returned when the statement is invalid as
+// BuildInsert.
+func (g *Generator) InsertByStruct(target interface{}) (string, []interface{}, error) {
+	if g == nil {
+		return "", nil, nil
+	}
+
+	columns, records, err := getRecords(target)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return g.BuildInsert(columns, records...)
+}
+
+// Insert return insert statement and params, the empty statement is
+// returned when the clauses are not supported by the dialect. Only the first
+// record is inserted by the conditional insert statement
 func (g *Generator) Insert(columns []string, records ...[]interface{}) (string, []interface{}) {
-	if g == nil || len(columns) == 0 || len(records) == 0 {
+	if g == nil || g.derived() || len(columns) == 0 || len(records) == 0 || g.opts.validateInsert() != nil {
 		return "", nil
 	}
 
+	var sql string
+	var params []interface{}
 	switch {
-	case !g.opts.where.Empty():
-		return g.insertWithWhereCond(columns, records[0])
+	case g.HasCondition():
+		sql, params = g.insertWithWhereCond(columns, records[0])
 	default:
-		return g.insertNormal(columns, records...)
+		sql, params = g.insertNormal(columns, records...)
+	}
+
+	return g.rebind(sql, params)
+}
+
+// BuildInsert return insert statement and params, the error is returned
+// when the columns or records is empty, the length of record is different
+// from the columns, multiple records are inserted with the conditions or the
+// statement is invalid
+func (g *Generator) BuildInsert(columns []string, records ...[]interface{}) (string, []interface{}, error) {
+	if err := g.validateInsert(columns, records); err != nil {
+		return "", nil, err
 	}
+
+	sql, params := g.Insert(columns, records...)
+	return sql, params, nil
 }
 
 func (g *Generator) insertNormal(columns []string, records ...[]interface{}) (string, []interface{}) {
@@ -124,14 +369,17 @@ func (g *Generator) insertNormal(columns []string, records ...[]interface{}) (st
 		return "", nil
 	}
 
-	onDuplicateKeyUpdate, updateParams := g.opts.genOnDuplicateKeyUpdate()
-	sql := bytes.NewBufferString(fmt.Sprintf("INSERT INTO %s", internal.SafeName(g.table)))
-	fmt.Fprintf(sql, " (%s)", strings.Join(internal.SafeNames(columns), ", "))
+	onDuplicateKeyUpdate, updateParams := g.opts.genOnDuplicateKeyUpdate(columns)
+	output, returning := g.opts.genReturning("inserted")
+	sql := bytes.NewBufferString(fmt.Sprintf("%s %s", g.opts.genInsertInto(), g.safeName(g.table)))
+	fmt.Fprintf(sql, " (%s)", strings.Join(internal.SafeNames(g.opts.dialect, columns), ", "))
+	sql.WriteString(sqlOrEmpty(output))
 	fmt.Fprintf(sql, " VALUES (%s)", strings.Repeat(",?", len(records[0]))[1:])
 	for i := 1; i < len(records); i++ {
 		fmt.Fprintf(sql, ", (%s)", strings.Repeat(",?", len(records[i]))[1:])
 	}
 	sql.WriteString(sqlOrEmpty(onDuplicateKeyUpdate))
+	sql.WriteString(sqlOrEmpty(returning))
 
 	var params []interface{}
 	for _, v := range records {
@@ -147,11 +395,15 @@ func (g *Generator) insertWithWhereCond(columns []string, record []interface{})
 		return "", nil
 	}
 
-	where, whereParams := g.opts.genWhere()
-	sql := bytes.NewBufferString(fmt.Sprintf("INSERT INTO %s", internal.SafeName(g.table)))
-	fmt.Fprintf(sql, " (%s)", strings.Join(internal.SafeNames(columns), ", "))
-	fmt.Fprintf(sql, " SELECT %s FROM dual", strings.Repeat(",?", len(record))[1:])
+	where, whereParams := g.opts.genFoldedWhere()
+	output, returning := g.opts.genReturning("inserted")
+	sql := bytes.NewBufferString(fmt.Sprintf("%s %s", g.opts.genInsertInto(), g.safeName(g.table)))
+	fmt.Fprintf(sql, " (%s)", strings.Join(internal.SafeNames(g.opts.dialect, columns), ", "))
+	sql.WriteString(sqlOrEmpty(output))
+	fmt.Fprintf(sql, " SELECT %s", strings.Repeat(",?", len(record))[1:])
+	sql.WriteString(sqlOrEmpty(g.opts.dialect.genDual()))
 	sql.WriteString(sqlOrEmpty(where))
+	sql.WriteString(sqlOrEmpty(returning))
 
 	var params []interface{}
 	params = append(params, record...)
@@ -160,7 +412,159 @@ func (g *Generator) insertWithWhereCond(columns []string, record []interface{})
 	return sql.String(), params
 }
 
-func getColumns(target interface{}) ([]string, error) {
+// withDialect return the shallow copy of generator with the dialect
+func (g *Generator) withDialect(d internal.Dialect) *Generator {
+	dialect, ok := d.(Dialect)
+	if g == nil || !ok || dialect == g.opts.dialect {
+		return g
+	}
+
+	if g.opts.strict && !internal.Strict(dialect) {
+		dialect = strictDialect{dialect}
+	}
+
+	opts := *g.opts
+	opts.dialect = dialect
+	return &Generator{
+		table:   g.table,
+		from:    g.from,
+		setOp:   g.setOp,
+		options: g.options,
+		opts:    &opts,
+	}
+}
+
+// validate the table and options of generator
+func (g *Generator) validate() error {
+	if g == nil {
+		return ErrNilGenerator
+	}
+
+	if g.setOp != nil {
+		for _, v := range g.setOp.queries {
+			if err := v.validateSelect(nil); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := internal.ValidateName(g.opts.dialect, g.table); err != nil {
+		return err
+	}
+
+	if g.from != nil && g.table == "" {
+		return ErrEmptyAlias
+	}
+
+	if err := internal.Validate(g.opts.dialect, g.from); err != nil {
+		return err
+	}
+
+	return g.opts.validate()
+}
+
+func (g *Generator) validateSelect(columns []interface{}) error {
+	if err := g.validate(); err != nil {
+		return err
+	}
+
+	return internal.ValidateExprs(g.opts.dialect, columns...)
+}
+
+func (g *Generator) validateUpdate(assExpr *AssExpr) error {
+	switch {
+	case g == nil:
+		return ErrNilGenerator
+	case g.derived():
+		return ErrDerivedGenerator
+	case assExpr.empty():
+		return ErrEmptyAssignment
+	}
+
+	if err := g.validate(); err != nil {
+		return err
+	}
+
+	if err := g.opts.validateMutation(); err != nil {
+		return err
+	}
+
+	return assExpr.validate(g.opts.dialect)
+}
+
+func (g *Generator) validateDelete() error {
+	if g != nil && g.derived() {
+		return ErrDerivedGenerator
+	}
+
+	if err := g.validate(); err != nil {
+		return err
+	}
+
+	return g.opts.validateMutation()
+}
+
+func (g *Generator) validateInsert(columns []string, records [][]interface{}) error {
+	switch {
+	case g == nil:
+		return ErrNilGenerator
+	case g.derived():
+		return ErrDerivedGenerator
+	case len(columns) == 0:
+		return ErrEmptyColumns
+	case len(records) == 0:
+		return ErrEmptyRecords
+	case len(records) > 1 && g.HasCondition():
+		return fmt.Errorf("%w: got %d records", ErrMultipleRecords, len(records))
+	}
+
+	for i, v := range records {
+		if len(v) != len(columns) {
+			return fmt.Errorf("%w: record %d has %d values, want %d", ErrRecordLengthMismatch, i, len(v), len(columns))
+		}
+
+		if err := internal.ValidateValues(v...); err != nil {
+			return err
+		}
+	}
+
+	if err := g.validate(); err != nil {
+		return err
+	}
+
+	if err := g.opts.validateInsert(); err != nil {
+		return err
+	}
+
+	return internal.ValidateNames(g.opts.dialect, columns...)
+}
+
+// HasCondition return true when the generator has WHERE or PREWHERE
+// condition
+func (g *Generator) HasCondition() bool {
+	return g != nil && !(g.opts.where.Empty() && g.opts.prewhere.Empty())
+}
+
+// derived return true when the generator select from the derived table or
+// the set operation, which can only generate select statement
+func (g *Generator) derived() bool {
+	return g.from != nil || g.setOp != nil
+}
+
+func (g *Generator) safeName(name string) string {
+	return internal.SafeName(g.opts.dialect, name)
+}
+
+// rebind inline the trusted expressions bound as params, and replace the ?
+// placeholders with the placeholders of dialect
+func (g *Generator) rebind(sql string, params []interface{}) (string, []interface{}) {
+	sql, params = internal.BindRaw(g.opts.dialect, sql, params)
+	return internal.Rebind(g.opts.dialect, sql), params
+}
+
+// getTargetInfo return the metadata of the target, which can be structure,
+// pointer or slice of them
+func getTargetInfo(target interface{}) (*internal.StructInfo, error) {
 	if target == nil {
 		return nil, errors.New("target can not be empty")
 	}
@@ -170,22 +574,15 @@ func getColumns(target interface{}) ([]string, error) {
 		targetType = targetType.Elem()
 	}
 
-	var columns []string
-	for i := 0; i < targetType.NumField(); i++ {
-		column := targetType.Field(i).Tag.Get("db")
-		if column == "" || column == "-" {
-			continue
-		}
-		column = internal.SafeName(column)
-
-		if expr := targetType.Field(i).Tag.Get("expr"); expr != "" {
-			column = expr
-		}
+	if kind := targetType.Kind(); kind == reflect.Slice || kind == reflect.Array {
+		targetType = targetType.Elem()
+	}
 
-		columns = append(columns, column)
+	if targetType = internal.StructType(targetType); targetType == nil {
+		return nil, fmt.Errorf("%w: %T", ErrUnsupportedTarget, target)
 	}
 
-	return columns, nil
+	return internal.GetStructInfo(targetType), nil
 }
 
 func sqlOrEmpty(str string) string {
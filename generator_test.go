@@ -118,6 +118,140 @@ func TestGenerator_Select(t *testing.T) {
 	assertParams(t, gotParams, wantParams)
 }
 
+func TestGenerator_SelectJoin(t *testing.T) {
+	orderOn := NewCompExpr()
+	orderOn.Put("o.user_id", EQColumn("u.id"))
+	orderOn.Put("o.status", EQ("paid"))
+
+	addressOn := NewCompExpr()
+	addressOn.Put("a.user_id", EQColumn("u.id"))
+
+	ops := []Option{
+		WithAlias("u"),
+		WithJoin("order", "o", orderOn),
+		WithLeftJoin("address", "a", addressOn),
+		WithRightJoin("profile", "", nil),
+		WithCrossJoin("region", "r"),
+		WithAnd("u.age", GT(18)),
+		WithOrderByDESC("o.created_at"),
+		WithLimit(10),
+	}
+
+	g := NewGenerator("user", ops...)
+	gotSQL, gotParams := g.Select("u.*", "o.id", "a.city")
+
+	wantSQL := "SELECT `u`.*, `o`.`id`, `a`.`city` FROM `user` AS `u` " +
+		"INNER JOIN `order` AS `o` ON `o`.`user_id`=`u`.`id` AND `o`.`status`=? " +
+		"LEFT JOIN `address` AS `a` ON `a`.`user_id`=`u`.`id` " +
+		"RIGHT JOIN `profile` " +
+		"CROSS JOIN `region` AS `r` " +
+		"WHERE `u`.`age`>? ORDER BY `o`.`created_at` DESC LIMIT 10"
+	wantParams := []interface{}{"paid", 18}
+
+	assertSQL(t, gotSQL, wantSQL)
+	assertParams(t, gotParams, wantParams)
+}
+
+func TestGenerator_SelectDerived(t *testing.T) {
+	orders := NewGenerator("orders", WithAnd("status", EQ("paid")), WithGroupBy("user_id"))
+
+	g := NewDerivedGenerator(orders, []interface{}{"user_id", Raw("COUNT(*) c")}, "t",
+		WithAnd("c", GT(10)),
+		WithOrderByDESC("c"),
+		WithLimit(20),
+		WithOffset(40))
+	gotSQL, gotParams := g.Select()
+
+	wantSQL := "SELECT * FROM (SELECT `user_id`, COUNT(*) c FROM `orders` WHERE `status`=? GROUP BY `user_id`) AS `t` " +
+		"WHERE `c`>? ORDER BY `c` DESC LIMIT 20 OFFSET 40"
+	wantParams := []interface{}{"paid", 10}
+	assertSQL(t, gotSQL, wantSQL)
+	assertParams(t, gotParams, wantParams)
+
+	// nested derived table
+	on := NewCompExpr()
+	on.Put("u.id", EQColumn("t2.user_id"))
+	g = NewDerivedGenerator(g, []interface{}{"user_id"}, "t2",
+		WithDialect(Postgres),
+		WithJoin("user", "u", on),
+		WithAnd("u.age", GT(18)))
+	gotSQL, gotParams = g.Select("u.name")
+
+	wantSQL = `SELECT "u"."name" FROM (SELECT "user_id" FROM (SELECT "user_id", COUNT(*) c FROM "orders" ` +
+		`WHERE "status"=$1 GROUP BY "user_id") AS "t" WHERE "c">$2 ORDER BY "c" DESC LIMIT 20 OFFSET 40) AS "t2" ` +
+		`INNER JOIN "user" AS "u" ON "u"."id"="t2"."user_id" WHERE "u"."age">$3`
+	wantParams = []interface{}{"paid", 10, 18}
+	assertSQL(t, gotSQL, wantSQL)
+	assertParams(t, gotParams, wantParams)
+
+	// derived table can only generate select statement
+	assExpr := NewAssExpr()
+	assExpr.Put("col", "val")
+	gotSQL, gotParams = g.Update(assExpr)
+	assertSQL(t, gotSQL, "")
+	assertParams(t, gotParams, nil)
+
+	gotSQL, gotParams = g.Delete()
+	assertSQL(t, gotSQL, "")
+	assertParams(t, gotParams, nil)
+}
+
+func TestGenerator_SelectCTE(t *testing.T) {
+	anchor := NewGenerator("category", WithColumns("id", "parent_id"), WithAnd("id", EQ(1)))
+
+	on := NewCompExpr()
+	on.Put("c.parent_id", EQColumn("t.id"))
+	recursive := NewGenerator("category", WithColumns("c.id", "c.parent_id"), WithAlias("c"), WithJoin("tree", "t", on),
+		WithAnd("c.deleted", EQ(false)))
+
+	paid := NewGenerator("order", WithColumns("user_id"), WithAnd("status", EQ("paid")))
+
+	g := NewGenerator("tree",
+		WithRecursiveCTE("tree", anchor, recursive),
+		WithCTE("paid", paid),
+		WithAnd("id", NEQ(1)))
+	gotSQL, gotParams := g.Select("id")
+
+	wantSQL := "WITH RECURSIVE `tree` AS (SELECT `id`, `parent_id` FROM `category` WHERE `id`=? UNION ALL " +
+		"SELECT `c`.`id`, `c`.`parent_id` FROM `category` AS `c` INNER JOIN `tree` AS `t` ON `c`.`parent_id`=`t`.`id` " +
+		"WHERE `c`.`deleted`=?), `paid` AS (SELECT `user_id` FROM `order` WHERE `status`=?) " +
+		"SELECT `id` FROM `tree` WHERE `id`!=?"
+	wantParams := []interface{}{1, false, "paid", 1}
+	assertSQL(t, gotSQL, wantSQL)
+	assertParams(t, gotParams, wantParams)
+
+	// without RECURSIVE keyword
+	g = NewGenerator("tree", WithDialect(TSQL), WithRecursiveCTE("tree", anchor, recursive))
+	gotSQL, gotParams = g.Select()
+
+	wantSQL = "WITH [tree] AS (SELECT [id], [parent_id] FROM [category] WHERE [id]=@p1 UNION ALL " +
+		"SELECT [c].[id], [c].[parent_id] FROM [category] AS [c] INNER JOIN [tree] AS [t] ON [c].[parent_id]=[t].[id] " +
+		"WHERE [c].[deleted]=@p2) SELECT * FROM [tree]"
+	assertSQL(t, gotSQL, wantSQL)
+	assertParams(t, gotParams, []interface{}{1, false})
+
+	// UPDATE
+	assExpr := NewAssExpr()
+	assExpr.Put("vip", true)
+	g = NewGenerator("user", WithDialect(Postgres), WithCTE("paid", paid), WithAnd("id", InQuery(NewGenerator("paid"), "user_id")))
+	gotSQL, gotParams = g.Update(assExpr)
+
+	wantSQL = `WITH "paid" AS (SELECT "user_id" FROM "order" WHERE "status"=$1) ` +
+		`UPDATE "user" SET "vip"=$2 WHERE "id" IN (SELECT "user_id" FROM "paid")`
+	assertSQL(t, gotSQL, wantSQL)
+	assertParams(t, gotParams, []interface{}{"paid", true})
+
+	// DELETE
+	g = NewGenerator("user", WithDialect(Postgres), WithCTE("paid", paid), WithAnd("id", NInQuery(NewGenerator("paid"), "user_id")),
+		WithAnd("age", LT(18)))
+	gotSQL, gotParams = g.Delete()
+
+	wantSQL = `WITH "paid" AS (SELECT "user_id" FROM "order" WHERE "status"=$1) ` +
+		`DELETE FROM "user" WHERE "id" NOT IN (SELECT "user_id" FROM "paid") AND "age"<$2`
+	assertSQL(t, gotSQL, wantSQL)
+	assertParams(t, gotParams, []interface{}{"paid", 18})
+}
+
 func TestGenerator_SelectByStrct(t *testing.T) {
 	m := NewCompExpr()
 	m.Put("comp_col_eq", EQ("comp_val_eq"))
@@ -312,6 +446,42 @@ func TestGenerator_Insert(t *testing.T) {
 	assertSQL(t, gotSQL, wantSQL)
 	assertParams(t, gotParams, wantParams)
 
+	// INSERT INTO ON DUPLICATE KEY UPDATE VALUES
+	opts = []Option{
+		OnConflictUpdate("col_2", "col_3"),
+	}
+	g = NewGenerator("table_name", opts...)
+	gotSQL, gotParams = g.Insert(columns, records[0])
+	wantSQL = "INSERT INTO `table_name` (`col_1`, `col_2`, `col_3`) VALUES (?,?,?) " +
+		"ON DUPLICATE KEY UPDATE `col_2`=VALUES(`col_2`), `col_3`=VALUES(`col_3`)"
+	wantParams = []interface{}{"col_1_1", "col_2_1", "col_3_1"}
+	assertSQL(t, gotSQL, wantSQL)
+	assertParams(t, gotParams, wantParams)
+
+	// REPLACE INTO
+	g = NewGenerator("table_name", InsertOrReplace())
+	gotSQL, gotParams = g.Insert(columns, records[0])
+	wantSQL = "REPLACE INTO `table_name` (`col_1`, `col_2`, `col_3`) VALUES (?,?,?)"
+	assertSQL(t, gotSQL, wantSQL)
+	assertParams(t, gotParams, wantParams)
+
+	// REPLACE INTO can not be combined with ON DUPLICATE KEY UPDATE
+	g = NewGenerator("table_name", InsertOrReplace(), OnConflictUpdate("col_2"))
+	gotSQL, gotParams = g.Insert(columns, records[0])
+	assertSQL(t, gotSQL, "")
+	assertParams(t, gotParams, nil)
+
+	if _, _, err := g.BuildInsert(columns, records[0]); !errors.Is(err, ErrUnsupportedClause) {
+		t.Errorf("got error dose not meet the expected\nexpected: %v\n  actual: %v", ErrUnsupportedClause, err)
+	}
+
+	// INSERT IGNORE INTO
+	g = NewGenerator("table_name", InsertOrIgnore())
+	gotSQL, gotParams = g.Insert(columns, records[0])
+	wantSQL = "INSERT IGNORE INTO `table_name` (`col_1`, `col_2`, `col_3`) VALUES (?,?,?)"
+	assertSQL(t, gotSQL, wantSQL)
+	assertParams(t, gotParams, wantParams)
+
 	// INSERT INTO WHERE NOT EXIST
 	exprCom := NewCompExpr()
 	exprCom.Put("col_1", GTE("val_gte"))
@@ -328,6 +498,222 @@ func TestGenerator_Insert(t *testing.T) {
 	assertParams(t, gotParams, wantParams)
 }
 
+func TestGenerator_Build(t *testing.T) {
+	assExpr := NewAssExpr()
+	assExpr.Put("name", "foo")
+
+	tests := []struct {
+		name    string
+		build   func() (string, []interface{}, error)
+		wantSQL string
+		wantErr error
+	}{
+		{
+			name: "select",
+			build: func() (string, []interface{}, error) {
+				return NewGenerator("user", WithAnd("id", In([]interface{}{1, 2}))).BuildSelect("id")
+			},
+			wantSQL: "SELECT `id` FROM `user` WHERE `id` IN (?,?)",
+		},
+		{
+			name: "select with empty in",
+			build: func() (string, []interface{}, error) {
+				return NewGenerator("user", WithAnd("id", In([]interface{}{}))).BuildSelect("id")
+			},
+			wantErr: ErrEmptyIn,
+		},
+		{
+			name: "select with empty in of subquery",
+			build: func() (string, []interface{}, error) {
+				sub := NewGenerator("order", WithColumns("user_id"), WithOr("id", NIn(nil)))
+				return NewGenerator("user", WithAnd("id", InQuery(sub))).BuildSelect()
+			},
+			wantErr: ErrEmptyIn,
+		},
+		{
+			name: "select with unsafe column",
+			build: func() (string, []interface{}, error) {
+				return NewGenerator("user").BuildSelect("id; DROP TABLE user")
+			},
+			wantErr: ErrUnsafeIdentifier,
+		},
+		{
+			name: "select with unsafe where column",
+			build: func() (string, []interface{}, error) {
+				return NewGenerator("user", WithAnd("id=1 --", EQ(1))).BuildSelect()
+			},
+			wantErr: ErrUnsafeIdentifier,
+		},
+		{
+			name: "select from nil generator",
+			build: func() (string, []interface{}, error) {
+				var g *Generator
+				return g.BuildSelect()
+			},
+			wantErr: ErrNilGenerator,
+		},
+		{
+			name: "update",
+			build: func() (string, []interface{}, error) {
+				return NewGenerator("user", WithAnd("id", EQ(1))).BuildUpdate(assExpr)
+			},
+			wantSQL: "UPDATE `user` SET `name`=? WHERE `id`=?",
+		},
+		{
+			name: "update with nil assignment",
+			build: func() (string, []interface{}, error) {
+				return NewGenerator("user").BuildUpdate(nil)
+			},
+			wantErr: ErrEmptyAssignment,
+		},
+		{
+			name: "update with empty assignment",
+			build: func() (string, []interface{}, error) {
+				return NewGenerator("user").BuildUpdate(NewAssExpr())
+			},
+			wantErr: ErrEmptyAssignment,
+		},
+		{
+			name: "update derived generator",
+			build: func() (string, []interface{}, error) {
+				return NewDerivedGenerator(NewGenerator("user"), nil, "t").BuildUpdate(assExpr)
+			},
+			wantErr: ErrDerivedGenerator,
+		},
+		{
+			name: "select derived generator with empty alias",
+			build: func() (string, []interface{}, error) {
+				return NewDerivedGenerator(NewGenerator("user"), nil, "").BuildSelect()
+			},
+			wantErr: ErrEmptyAlias,
+		},
+		{
+			name: "delete",
+			build: func() (string, []interface{}, error) {
+				return NewGenerator("user", WithAnd("id", NIn([]interface{}{1}))).BuildDelete()
+			},
+			wantSQL: "DELETE FROM `user` WHERE `id` NOT IN (?)",
+		},
+		{
+			name: "delete with unsafe table",
+			build: func() (string, []interface{}, error) {
+				return NewGenerator("user/**/").BuildDelete()
+			},
+			wantErr: ErrUnsafeIdentifier,
+		},
+		{
+			name: "insert",
+			build: func() (string, []interface{}, error) {
+				return NewGenerator("user").BuildInsert([]string{"id", "name"}, []interface{}{1, "foo"}, []interface{}{2, "bar"})
+			},
+			wantSQL: "INSERT INTO `user` (`id`, `name`) VALUES (?,?), (?,?)",
+		},
+		{
+			name: "insert with mismatched record",
+			build: func() (string, []interface{}, error) {
+				return NewGenerator("user").BuildInsert([]string{"id", "name"}, []interface{}{1, "foo"}, []interface{}{2})
+			},
+			wantErr: ErrRecordLengthMismatch,
+		},
+		{
+			name: "insert with empty columns",
+			build: func() (string, []interface{}, error) {
+				return NewGenerator("user").BuildInsert(nil, []interface{}{1})
+			},
+			wantErr: ErrEmptyColumns,
+		},
+		{
+			name: "insert with empty records",
+			build: func() (string, []interface{}, error) {
+				return NewGenerator("user").BuildInsert([]string{"id"})
+			},
+			wantErr: ErrEmptyRecords,
+		},
+		{
+			name: "insert multiple records with where condition",
+			build: func() (string, []interface{}, error) {
+				return NewGenerator("user", WithAnd("id", NEQ(1))).BuildInsert([]string{"id"}, []interface{}{1}, []interface{}{2})
+			},
+			wantErr: ErrMultipleRecords,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotSQL, _, err := tt.build()
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("got error dose not meet the expected\nexpected: %v\n  actual: %v", tt.wantErr, err)
+			}
+
+			assertSQL(t, gotSQL, tt.wantSQL)
+		})
+	}
+}
+
+func TestGenerator_StrictIdentifier(t *testing.T) {
+	sort := "id DESC; DROP TABLE user"
+
+	// the name is quoted as identifier by default
+	g := NewGenerator("user", WithOrderBy(sort))
+	gotSQL, _ := g.Select("id", "u.*", Count("*"))
+	assertSQL(t, gotSQL, "SELECT `id`, `u`.*, COUNT(*) FROM `user` ORDER BY `id DESC; DROP TABLE user` ASC")
+
+	_, _, err := g.BuildSelect("id")
+	if !errors.Is(err, ErrUnsafeIdentifier) {
+		t.Errorf("got error dose not meet the expected\nexpected: %v\n  actual: %v", ErrUnsafeIdentifier, err)
+	}
+
+	// the expression is passed through in loose mode
+	g = NewGenerator("user", WithOrderBy("FIELD(status, 'paid')"), WithLooseIdentifier())
+	gotSQL, _ = g.Select("id", "COUNT(*) c")
+	assertSQL(t, gotSQL, "SELECT `id`, COUNT(*) c FROM `user` ORDER BY FIELD(status, 'paid') ASC")
+
+	// the strict mode can be enabled again
+	gotSQL, _ = NewGenerator("user", WithLooseIdentifier(), WithStrictIdentifier()).Select("COUNT(*) c")
+	assertSQL(t, gotSQL, "SELECT `COUNT(*) c` FROM `user`")
+
+	// the embedded quote symbol is escaped
+	gotSQL, _ = NewGenerator("user").Select("a`,`b", "`c`")
+	assertSQL(t, gotSQL, "SELECT `a``,``b`, `c` FROM `user`")
+
+	gotSQL, _ = NewGenerator("user", WithDialect(TSQL)).Select("a]b", Max("c(d)"))
+	assertSQL(t, gotSQL, "SELECT [a]]b], MAX([c(d)]) FROM [user]")
+
+	// the strict mode is inherited by subquery in loose mode
+	sub := NewGenerator("order", WithColumns("user_id"), WithAnd("amount > 0 OR 1=1", GT(100)), WithLooseIdentifier())
+	g = NewGenerator("user", WithAnd("id", InQuery(sub)))
+	gotSQL, _ = g.Select(Raw("COUNT(*)"))
+	assertSQL(t, gotSQL, "SELECT COUNT(*) FROM `user` WHERE `id` IN (SELECT `user_id` FROM `order` WHERE `amount > 0 OR 1=1`>?)")
+
+	_, _, err = g.BuildSelect()
+	if !errors.Is(err, ErrUnsafeIdentifier) {
+		t.Errorf("got error dose not meet the expected\nexpected: %v\n  actual: %v", ErrUnsafeIdentifier, err)
+	}
+}
+
+func TestGenerator_HasCondition(t *testing.T) {
+	tests := []struct {
+		name string
+		g    *Generator
+		want bool
+	}{
+		{name: "nil", g: nil, want: false},
+		{name: "without condition", g: NewGenerator("user", WithLimit(1), WithOrderBy("id")), want: false},
+		{name: "empty condition", g: NewGenerator("user", WithAndCond(And())), want: false},
+		{name: "where", g: NewGenerator("user", WithAnd("id", EQ(1))), want: true},
+		{name: "prewhere", g: NewGenerator("user", WithPrewhere("id", EQ(1))), want: true},
+		{name: "appended", g: NewGenerator("user").With(WithOr("id", EQ(1))), want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.g.HasCondition(); got != tt.want {
+				t.Errorf("HasCondition() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func assertSQL(t *testing.T, got, want string) {
 	if got != want {
 		t.Errorf("got sql dose not meet the expected\nexpected: %s\n  actual: %s", want, got)
@@ -17,7 +17,7 @@ func (c *Condition) Append(expr Expression) {
 }
 
 // ToSQL return condition of statement
-func (c *Condition) ToSQL() (string, []interface{}) {
+func (c *Condition) ToSQL(d Dialect) (string, []interface{}) {
 	if c == nil {
 		return "", nil
 	}
@@ -25,15 +25,47 @@ func (c *Condition) ToSQL() (string, []interface{}) {
 	buffer := bytes.NewBuffer(nil)
 	values := make([]interface{}, 0, len(c.exprs))
 	for _, v := range c.exprs {
-		sql, val := v.ToSQL()
+		sql, val := v.ToSQL(d)
+		if sql == "" {
+			continue
+		}
+
 		fmt.Fprintf(buffer, " %s", sql)
 		values = append(values, val...)
 	}
 
 	sql := strings.TrimSpace(buffer.String())
+	if sql == "" {
+		return "", nil
+	}
+
 	return sql[strings.Index(sql, " ")+1:], values
 }
 
+// Validate the expressions of condition
+func (c *Condition) Validate(d Dialect) error {
+	if c == nil {
+		return nil
+	}
+
+	for _, v := range c.exprs {
+		if err := Validate(d, v); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Exprs return the expressions of condition
+func (c *Condition) Exprs() []Expression {
+	if c == nil {
+		return nil
+	}
+
+	return c.exprs
+}
+
 // Empty will return true, when there is no expression
 func (c *Condition) Empty() bool {
 	return c == nil || len(c.exprs) == 0
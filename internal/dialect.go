@@ -0,0 +1,138 @@
+package internal
+
+import (
+	"bytes"
+	"strings"
+)
+
+// Dialect of SQL statement
+type Dialect interface {
+	// Quote return quoted identifier
+	Quote(name string) string
+
+	// Placeholder return placeholder of the n-th param, n start from 1
+	Placeholder(n int) string
+}
+
+// Strict return true when the dialect is in strict identifier mode, the
+// identifier will never be treated as expression in strict mode
+func Strict(d Dialect) bool {
+	s, ok := d.(interface{ Strict() bool })
+	return ok && s.Strict()
+}
+
+// Quote identifier with the left and right quote symbol
+//
+// The embedded right quote symbol is escaped by doubling, the identifier
+// which is already quoted will not be quoted again.
+func Quote(name string, left, right string) string {
+	if unquoted, ok := unquote(name, left, right); ok {
+		name = unquoted
+	}
+
+	return left + strings.ReplaceAll(name, right, right+right) + right
+}
+
+// unquote return the unescaped identifier, the identifier is quoted when it's
+// surrounded by the quote symbols and all the embedded right quote symbols
+// are escaped
+func unquote(name string, left, right string) (string, bool) {
+	if len(name) < len(left)+len(right) || !strings.HasPrefix(name, left) || !strings.HasSuffix(name, right) {
+		return name, false
+	}
+
+	escaped := right + right
+	inner := name[len(left) : len(name)-len(right)]
+	if strings.Contains(strings.ReplaceAll(inner, escaped, ""), right) {
+		return name, false
+	}
+
+	return strings.ReplaceAll(inner, escaped, right), true
+}
+
+// Rebind replace the ? placeholders of statement with the placeholders of dialect
+//
+// The ? in quoted string or identifier will be ignored.
+func Rebind(d Dialect, sql string) string {
+	if d == nil || d.Placeholder(1) == "?" {
+		return sql
+	}
+
+	buffer := bytes.NewBuffer(make([]byte, 0, len(sql)))
+	var quote byte
+	var n int
+	for i := 0; i < len(sql); i++ {
+		c := sql[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'', c == '"', c == '`':
+			quote = c
+		case c == '?':
+			n++
+			buffer.WriteString(d.Placeholder(n))
+			continue
+		}
+
+		buffer.WriteByte(c)
+	}
+
+	return buffer.String()
+}
+
+// Requote replace the backtick quoted identifiers of expression with the
+// quoted identifiers of dialect
+//
+// The backtick in quoted string will be ignored, the doubled backtick in
+// quoted identifier is treated as escaped backtick.
+func Requote(d Dialect, sql string) string {
+	if d == nil || d.Quote("x") == "`x`" || !strings.Contains(sql, "`") {
+		return sql
+	}
+
+	buffer := bytes.NewBuffer(make([]byte, 0, len(sql)))
+	var quoted bool
+	for i := 0; i < len(sql); i++ {
+		c := sql[i]
+		switch {
+		case c == '\'' && !quoted:
+			quoted = true
+		case c == '\'' && quoted:
+			quoted = false
+		case c == '`' && !quoted:
+			end := closingBacktick(sql[i+1:])
+			if end < 0 {
+				break
+			}
+
+			buffer.WriteString(d.Quote(strings.ReplaceAll(sql[i+1:i+1+end], "``", "`")))
+			i += end + 1
+			continue
+		}
+
+		buffer.WriteByte(c)
+	}
+
+	return buffer.String()
+}
+
+// closingBacktick return the index of the closing backtick, the doubled
+// backtick is skipped
+func closingBacktick(sql string) int {
+	for i := 0; i < len(sql); i++ {
+		if sql[i] != '`' {
+			continue
+		}
+
+		if i+1 < len(sql) && sql[i+1] == '`' {
+			i++
+			continue
+		}
+
+		return i
+	}
+
+	return -1
+}
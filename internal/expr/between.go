@@ -6,19 +6,22 @@ import (
 	"github.com/wwwangxc/sqlg/internal"
 )
 
-var _ internal.Expression = (*Between)(nil)
+var (
+	_ internal.Expression = (*Between)(nil)
+	_ internal.Validator  = (*Between)(nil)
+)
 
 // Between expression
 type Between struct {
 	op     internal.Operator
-	column string
+	column interface{}
 	value1 interface{}
 	value2 interface{}
 	isNot  bool
 }
 
 // NewBetween create between expression structure
-func NewBetween(op internal.Operator, column string, value1 interface{}, value2 interface{}) *Between {
+func NewBetween(op internal.Operator, column interface{}, value1 interface{}, value2 interface{}) *Between {
 	return &Between{
 		op:     op,
 		column: column,
@@ -29,7 +32,7 @@ func NewBetween(op internal.Operator, column string, value1 interface{}, value2
 }
 
 // NewNBetween create not between expression structure
-func NewNBetween(op internal.Operator, column string, value1 interface{}, value2 interface{}) *Between {
+func NewNBetween(op internal.Operator, column interface{}, value1 interface{}, value2 interface{}) *Between {
 	return &Between{
 		op:     op,
 		column: column,
@@ -40,7 +43,7 @@ func NewNBetween(op internal.Operator, column string, value1 interface{}, value2
 }
 
 // ToSQL return between expression
-func (b *Between) ToSQL() (string, []interface{}) {
+func (b *Between) ToSQL(d internal.Dialect) (string, []interface{}) {
 	if b == nil {
 		return "", nil
 	}
@@ -50,6 +53,19 @@ func (b *Between) ToSQL() (string, []interface{}) {
 		symbol = "NOT "
 	}
 
-	return fmt.Sprintf("%s %s %sBETWEEN ? AND ?", b.op, internal.SafeName(b.column), symbol),
+	return fmt.Sprintf("%s %s %sBETWEEN ? AND ?", b.op, internal.SafeName(d, b.column), symbol),
 		[]interface{}{b.value1, b.value2}
 }
+
+// Validate the column and values of between expression
+func (b *Between) Validate(d internal.Dialect) error {
+	if b == nil {
+		return nil
+	}
+
+	if err := internal.ValidateName(d, b.column); err != nil {
+		return err
+	}
+
+	return internal.ValidateValues(b.value1, b.value2)
+}
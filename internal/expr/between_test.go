@@ -79,12 +79,12 @@ func TestBetween_ToSQL(t *testing.T) {
 				value2: tt.fields.value2,
 				isNot:  tt.fields.isNot,
 			}
-			got, got1 := b.ToSQL()
+			got, got1 := b.ToSQL(nil)
 			if got != tt.want {
-				t.Errorf("Between.ToSQL() got = %v, want %v", got, tt.want)
+				t.Errorf("Between.ToSQL(nil) got = %v, want %v", got, tt.want)
 			}
 			if !reflect.DeepEqual(got1, tt.want1) {
-				t.Errorf("Between.ToSQL() got1 = %v, want %v", got1, tt.want1)
+				t.Errorf("Between.ToSQL(nil) got1 = %v, want %v", got1, tt.want1)
 			}
 		})
 	}
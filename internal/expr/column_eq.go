@@ -0,0 +1,50 @@
+package expr
+
+import (
+	"fmt"
+
+	"github.com/wwwangxc/sqlg/internal"
+)
+
+var (
+	_ internal.Expression = (*ColumnEQ)(nil)
+	_ internal.Validator  = (*ColumnEQ)(nil)
+)
+
+// ColumnEQ column equal to another column expression
+type ColumnEQ struct {
+	op     internal.Operator
+	column interface{}
+	other  string
+}
+
+// NewColumnEQ create column equal to another column expression structure
+func NewColumnEQ(op internal.Operator, column interface{}, other string) *ColumnEQ {
+	return &ColumnEQ{
+		op:     op,
+		column: column,
+		other:  other,
+	}
+}
+
+// ToSQL return column equal to another column expression
+func (c *ColumnEQ) ToSQL(d internal.Dialect) (string, []interface{}) {
+	if c == nil {
+		return "", nil
+	}
+
+	return fmt.Sprintf("%s %s=%s", c.op, internal.SafeName(d, c.column), internal.SafeName(d, c.other)), nil
+}
+
+// Validate the columns of column equal expression
+func (c *ColumnEQ) Validate(d internal.Dialect) error {
+	if c == nil {
+		return nil
+	}
+
+	if err := internal.ValidateName(d, c.column); err != nil {
+		return err
+	}
+
+	return internal.ValidateName(d, c.other)
+}
@@ -0,0 +1,59 @@
+package expr
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/wwwangxc/sqlg/internal"
+)
+
+func TestColumnEQ_ToSQL(t *testing.T) {
+	type fields struct {
+		op     internal.Operator
+		column string
+		other  string
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   string
+		want1  []interface{}
+	}{
+		{
+			name: "and",
+			fields: fields{
+				op:     internal.OperatorAnd,
+				column: "col1",
+				other:  "col2",
+			},
+			want:  "AND `col1`=`col2`",
+			want1: nil,
+		},
+		{
+			name: "or with table",
+			fields: fields{
+				op:     internal.OperatorOr,
+				column: "t1.col1",
+				other:  "t2.col2",
+			},
+			want:  "OR `t1`.`col1`=`t2`.`col2`",
+			want1: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &ColumnEQ{
+				op:     tt.fields.op,
+				column: tt.fields.column,
+				other:  tt.fields.other,
+			}
+			got, got1 := c.ToSQL(nil)
+			if got != tt.want {
+				t.Errorf("ColumnEQ.ToSQL() got = %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(got1, tt.want1) {
+				t.Errorf("ColumnEQ.ToSQL() got1 = %v, want %v", got1, tt.want1)
+			}
+		})
+	}
+}
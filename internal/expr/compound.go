@@ -8,7 +8,10 @@ import (
 	"github.com/wwwangxc/sqlg/internal"
 )
 
-var _ internal.Expression = (*Compound)(nil)
+var (
+	_ internal.Expression = (*Compound)(nil)
+	_ internal.Validator  = (*Compound)(nil)
+)
 
 // Compound expression
 type Compound struct {
@@ -25,20 +28,20 @@ func NewCompound(op internal.Operator, exprs ...internal.Expression) *Compound {
 }
 
 // ToSQL return compound expression
-func (c *Compound) ToSQL() (string, []interface{}) {
+func (c *Compound) ToSQL(d internal.Dialect) (string, []interface{}) {
 	if c == nil || len(c.exprs) == 0 {
 		return "", nil
 	}
 
 	if len(c.exprs) == 1 {
-		sql, values := c.exprs[0].ToSQL()
+		sql, values := c.exprs[0].ToSQL(d)
 		return fmt.Sprintf("%s %s", c.op, removeFirstOp(sql)), values
 	}
 
 	buffer := bytes.NewBuffer(nil)
 	values := make([]interface{}, 0, len(c.exprs))
 	for _, v := range c.exprs {
-		sql, vals := v.ToSQL()
+		sql, vals := v.ToSQL(d)
 		fmt.Fprintf(buffer, " %s", sql)
 		values = append(values, vals...)
 	}
@@ -51,3 +54,18 @@ func removeFirstOp(str string) string {
 	str = strings.TrimSpace(str)
 	return str[strings.Index(str, " ")+1:]
 }
+
+// Validate the expressions of compound expression
+func (c *Compound) Validate(d internal.Dialect) error {
+	if c == nil {
+		return nil
+	}
+
+	for _, v := range c.exprs {
+		if err := internal.Validate(d, v); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -1,6 +1,7 @@
 package expr
 
 import (
+	"errors"
 	"reflect"
 	"testing"
 
@@ -87,12 +88,51 @@ func TestCompound_ToSQL(t *testing.T) {
 				op:    tt.fields.op,
 				exprs: tt.fields.exprs,
 			}
-			got, got1 := c.ToSQL()
+			got, got1 := c.ToSQL(nil)
 			if got != tt.want {
-				t.Errorf("Compound.ToSQL() got = %v, want %v", got, tt.want)
+				t.Errorf("Compound.ToSQL(nil) got = %v, want %v", got, tt.want)
 			}
 			if !reflect.DeepEqual(got1, tt.want1) {
-				t.Errorf("Compound.ToSQL() got1 = %v, want %v", got1, tt.want1)
+				t.Errorf("Compound.ToSQL(nil) got1 = %v, want %v", got1, tt.want1)
+			}
+		})
+	}
+}
+
+func TestCompound_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		compound *Compound
+		wantErr  error
+	}{
+		{
+			name: "valid",
+			compound: NewCompound(internal.OperatorAnd,
+				NewEQ(internal.OperatorAnd, "col1", "val1"),
+				NewIn(internal.OperatorOr, "col2", []interface{}{"val2"})),
+		},
+		{
+			name: "empty in",
+			compound: NewCompound(internal.OperatorAnd,
+				NewEQ(internal.OperatorAnd, "col1", "val1"),
+				NewIn(internal.OperatorOr, "col2", nil)),
+			wantErr: internal.ErrEmptyIn,
+		},
+		{
+			name: "unsafe column of nested compound",
+			compound: NewCompound(internal.OperatorAnd,
+				NewCompound(internal.OperatorOr, NewGT(internal.OperatorAnd, "col1 -- ", "val1"))),
+			wantErr: internal.ErrUnsafeIdentifier,
+		},
+		{
+			name:     "nil",
+			compound: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.compound.Validate(nil); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
 	}
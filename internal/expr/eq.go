@@ -6,17 +6,20 @@ import (
 	"github.com/wwwangxc/sqlg/internal"
 )
 
-var _ internal.Expression = (*EQ)(nil)
+var (
+	_ internal.Expression = (*EQ)(nil)
+	_ internal.Validator  = (*EQ)(nil)
+)
 
 // EQ equal expression
 type EQ struct {
 	op     internal.Operator
-	column string
+	column interface{}
 	value  interface{}
 }
 
 // NewEQ create equal expression structure
-func NewEQ(op internal.Operator, column string, value interface{}) *EQ {
+func NewEQ(op internal.Operator, column interface{}, value interface{}) *EQ {
 	return &EQ{
 		op:     op,
 		column: column,
@@ -25,10 +28,23 @@ func NewEQ(op internal.Operator, column string, value interface{}) *EQ {
 }
 
 // ToSQL return equal expression
-func (e *EQ) ToSQL() (string, []interface{}) {
+func (e *EQ) ToSQL(d internal.Dialect) (string, []interface{}) {
 	if e == nil {
 		return "", nil
 	}
 
-	return fmt.Sprintf("%s %s=?", e.op, internal.SafeName(e.column)), []interface{}{e.value}
+	return fmt.Sprintf("%s %s=?", e.op, internal.SafeName(d, e.column)), []interface{}{e.value}
+}
+
+// Validate the column and value of equal expression
+func (e *EQ) Validate(d internal.Dialect) error {
+	if e == nil {
+		return nil
+	}
+
+	if err := internal.ValidateName(d, e.column); err != nil {
+		return err
+	}
+
+	return internal.ValidateValues(e.value)
 }
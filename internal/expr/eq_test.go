@@ -47,12 +47,12 @@ func TestEQ_ToSQL(t *testing.T) {
 				column: tt.fields.column,
 				value:  tt.fields.value,
 			}
-			got, got1 := e.ToSQL()
+			got, got1 := e.ToSQL(nil)
 			if got != tt.want {
-				t.Errorf("EQ.ToSQL() got = %v, want %v", got, tt.want)
+				t.Errorf("EQ.ToSQL(nil) got = %v, want %v", got, tt.want)
 			}
 			if !reflect.DeepEqual(got1, tt.want1) {
-				t.Errorf("EQ.ToSQL() got1 = %v, want %v", got1, tt.want1)
+				t.Errorf("EQ.ToSQL(nil) got1 = %v, want %v", got1, tt.want1)
 			}
 		})
 	}
@@ -7,7 +7,10 @@ import (
 	"github.com/wwwangxc/sqlg/internal"
 )
 
-var _ internal.Expression = (*Exists)(nil)
+var (
+	_ internal.Expression = (*Exists)(nil)
+	_ internal.Validator  = (*Exists)(nil)
+)
 
 // Exists expression
 type Exists struct {
@@ -38,7 +41,7 @@ func NewNExists(op internal.Operator, table string, exprs ...internal.Expression
 }
 
 // ToSQL return exists expression
-func (e *Exists) ToSQL() (string, []interface{}) {
+func (e *Exists) ToSQL(d internal.Dialect) (string, []interface{}) {
 	if e == nil || e.compExpr == nil || len(e.compExpr.exprs) == 0 {
 		return "", nil
 	}
@@ -48,7 +51,20 @@ func (e *Exists) ToSQL() (string, []interface{}) {
 		symbol = "NOT "
 	}
 
-	cond, params := e.compExpr.ToSQL()
+	cond, params := e.compExpr.ToSQL(d)
 	cond = strings.TrimRight(strings.TrimLeft(removeFirstOp(cond), "("), ")")
-	return fmt.Sprintf("%s %sEXISTS (SELECT * FROM %s WHERE %s)", e.op, symbol, internal.SafeName(e.table), cond), params
+	return fmt.Sprintf("%s %sEXISTS (SELECT * FROM %s WHERE %s)", e.op, symbol, internal.SafeName(d, e.table), cond), params
+}
+
+// Validate the table and expressions of exists expression
+func (e *Exists) Validate(d internal.Dialect) error {
+	if e == nil {
+		return nil
+	}
+
+	if err := internal.ValidateName(d, e.table); err != nil {
+		return err
+	}
+
+	return e.compExpr.Validate(d)
 }
@@ -0,0 +1,190 @@
+package expr
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/wwwangxc/sqlg/internal"
+)
+
+var (
+	_ internal.Expression = (*Group)(nil)
+	_ internal.Validator  = (*Group)(nil)
+)
+
+// precedence of boolean expression, the operand is parenthesized when its
+// precedence is lower than the operator
+type precedence uint8
+
+const (
+	precedenceOr precedence = iota + 1
+	precedenceAnd
+	precedenceNot
+	precedencePrimary
+)
+
+// Group boolean expression, the expressions are joined with the operator of
+// group, and the nested groups are parenthesized only when it's necessary
+type Group struct {
+	op    internal.Operator
+	join  internal.Operator
+	not   bool
+	exprs []internal.Expression
+}
+
+// NewGroup create group expression structure, the expressions are joined
+// with the join operator
+func NewGroup(op, join internal.Operator, exprs ...internal.Expression) *Group {
+	return &Group{
+		op:    op,
+		join:  join,
+		exprs: exprs,
+	}
+}
+
+// NewNot create negated group expression structure
+func NewNot(op internal.Operator, e internal.Expression) *Group {
+	return &Group{
+		op:    op,
+		join:  internal.OperatorAnd,
+		not:   true,
+		exprs: []internal.Expression{e},
+	}
+}
+
+// ToSQL return group expression, the multi-term group is always
+// parenthesized, since the precedence of the flat condition list it's
+// embedded into is unknown
+func (g *Group) ToSQL(d internal.Dialect) (string, []interface{}) {
+	sql, values, p := g.toSQL(d)
+	if sql == "" {
+		return "", nil
+	}
+
+	return fmt.Sprintf("%s %s", g.op, parenthesize(sql, p, precedenceNot)), values
+}
+
+// toSQL return group expression without operator and its precedence
+func (g *Group) toSQL(d internal.Dialect) (string, []interface{}, precedence) {
+	if g == nil {
+		return "", nil, 0
+	}
+
+	items := make([]string, 0, len(g.exprs))
+	values := make([]interface{}, 0, len(g.exprs))
+	var p precedence
+	for _, v := range g.exprs {
+		sql, vals, prec := operand(d, v)
+		if sql == "" {
+			continue
+		}
+
+		if !g.not {
+			sql = parenthesize(sql, prec, operatorPrecedence(g.join))
+		}
+
+		items = append(items, sql)
+		values = append(values, vals...)
+		p = prec
+	}
+
+	if len(items) == 0 {
+		return "", nil, 0
+	}
+
+	sql := items[0]
+	if len(items) > 1 {
+		sql = strings.Join(items, fmt.Sprintf(" %s ", g.join))
+		p = operatorPrecedence(g.join)
+	}
+
+	if g.not {
+		return fmt.Sprintf("NOT %s", parenthesize(sql, p, precedencePrimary)), values, precedenceNot
+	}
+
+	return sql, values, p
+}
+
+// operand return the expression without operator and its precedence
+func operand(d internal.Dialect, e internal.Expression) (string, []interface{}, precedence) {
+	if g, ok := e.(*Group); ok {
+		return g.toSQL(d)
+	}
+
+	if e == nil {
+		return "", nil, 0
+	}
+
+	sql, values := e.ToSQL(d)
+	if sql = strings.TrimSpace(sql); sql == "" {
+		return "", nil, 0
+	}
+
+	sql = removeFirstOp(sql)
+	if enclosed(sql) {
+		return sql, values, precedencePrimary
+	}
+
+	return sql, values, precedenceNot
+}
+
+func operatorPrecedence(op internal.Operator) precedence {
+	if op == internal.OperatorOr {
+		return precedenceOr
+	}
+
+	return precedenceAnd
+}
+
+func parenthesize(sql string, p, parent precedence) string {
+	if p < parent {
+		return fmt.Sprintf("(%s)", sql)
+	}
+
+	return sql
+}
+
+// enclosed return true when the whole sql is enclosed by parentheses
+func enclosed(sql string) bool {
+	if !strings.HasPrefix(sql, "(") {
+		return false
+	}
+
+	var quote byte
+	var depth int
+	for i := 0; i < len(sql); i++ {
+		c := sql[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'', c == '"', c == '`':
+			quote = c
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+			if depth == 0 {
+				return i == len(sql)-1
+			}
+		}
+	}
+
+	return false
+}
+
+// Validate the expressions of group expression
+func (g *Group) Validate(d internal.Dialect) error {
+	if g == nil {
+		return nil
+	}
+
+	for _, v := range g.exprs {
+		if err := internal.Validate(d, v); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
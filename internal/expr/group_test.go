@@ -0,0 +1,180 @@
+package expr
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/wwwangxc/sqlg/internal"
+)
+
+func TestGroup_ToSQL(t *testing.T) {
+	and, or := internal.OperatorAnd, internal.OperatorOr
+	tests := []struct {
+		name  string
+		group *Group
+		want  string
+		want1 []interface{}
+	}{
+		{
+			name:  "nil",
+			group: nil,
+			want:  "",
+			want1: nil,
+		},
+		{
+			name:  "empty",
+			group: NewGroup(and, or),
+			want:  "",
+			want1: nil,
+		},
+		{
+			name:  "one expr",
+			group: NewGroup(and, or, NewEQ(or, "col", "val")),
+			want:  "AND `col`=?",
+			want1: []interface{}{"val"},
+		},
+		{
+			name:  "same column",
+			group: NewGroup(and, and, NewGT(and, "age", 18), NewLT(or, "age", 65)),
+			want:  "AND (`age`>? AND `age`<?)",
+			want1: []interface{}{18, 65},
+		},
+		{
+			name:  "or joined with and",
+			group: NewGroup(and, or, NewEQ(and, "col1", 1), NewEQ(and, "col2", 2)),
+			want:  "AND (`col1`=? OR `col2`=?)",
+			want1: []interface{}{1, 2},
+		},
+		{
+			name:  "or joined with or",
+			group: NewGroup(or, or, NewEQ(and, "col1", 1), NewEQ(and, "col2", 2)),
+			want:  "OR (`col1`=? OR `col2`=?)",
+			want1: []interface{}{1, 2},
+		},
+		{
+			name: "and in or",
+			group: NewGroup(and, or,
+				NewGroup(and, and, NewGT(and, "age", 18), NewLT(and, "age", 65)),
+				NewEQ(and, "vip", 1)),
+			want:  "AND (`age`>? AND `age`<? OR `vip`=?)",
+			want1: []interface{}{18, 65, 1},
+		},
+		{
+			name: "or in and",
+			group: NewGroup(or, and,
+				NewGroup(and, or, NewEQ(and, "col1", 1), NewEQ(and, "col2", 2)),
+				NewGroup(and, or, NewEQ(and, "col3", 3), NewEQ(and, "col4", 4))),
+			want:  "OR ((`col1`=? OR `col2`=?) AND (`col3`=? OR `col4`=?))",
+			want1: []interface{}{1, 2, 3, 4},
+		},
+		{
+			name: "deep nested",
+			group: NewGroup(and, or,
+				NewGroup(and, and,
+					NewEQ(and, "col1", 1),
+					NewGroup(and, or,
+						NewEQ(and, "col2", 2),
+						NewGroup(and, and, NewEQ(and, "col3", 3), NewEQ(and, "col4", 4)))),
+				NewEQ(and, "col5", 5)),
+			want:  "AND (`col1`=? AND (`col2`=? OR `col3`=? AND `col4`=?) OR `col5`=?)",
+			want1: []interface{}{1, 2, 3, 4, 5},
+		},
+		{
+			name: "single nested group is flattened",
+			group: NewGroup(and, and,
+				NewGroup(and, or, NewGroup(and, or, NewEQ(and, "col1", 1), NewEQ(and, "col2", 2))),
+				NewGroup(and, and),
+				NewEQ(and, "col3", 3)),
+			want:  "AND ((`col1`=? OR `col2`=?) AND `col3`=?)",
+			want1: []interface{}{1, 2, 3},
+		},
+		{
+			name:  "not",
+			group: NewNot(and, NewEQ(and, "col", 1)),
+			want:  "AND NOT (`col`=?)",
+			want1: []interface{}{1},
+		},
+		{
+			name:  "not group",
+			group: NewNot(or, NewGroup(and, or, NewEQ(and, "col1", 1), NewEQ(and, "col2", 2))),
+			want:  "OR NOT (`col1`=? OR `col2`=?)",
+			want1: []interface{}{1, 2},
+		},
+		{
+			name:  "not enclosed expr",
+			group: NewNot(and, NewCompound(and, NewEQ(or, "col1", 1), NewEQ(or, "col2", 2))),
+			want:  "AND NOT (`col1`=? OR `col2`=?)",
+			want1: []interface{}{1, 2},
+		},
+		{
+			name:  "not not",
+			group: NewNot(and, NewNot(and, NewIn(and, "col", []interface{}{1, 2}))),
+			want:  "AND NOT (NOT (`col` IN (?,?)))",
+			want1: []interface{}{1, 2},
+		},
+		{
+			name:  "not empty",
+			group: NewNot(and, NewGroup(and, or)),
+			want:  "",
+			want1: nil,
+		},
+		{
+			name: "not in and",
+			group: NewGroup(and, and,
+				NewNot(and, NewEQ(and, "col1", 1)),
+				NewBetween(and, "col2", 1, 9)),
+			want:  "AND (NOT (`col1`=?) AND `col2` BETWEEN ? AND ?)",
+			want1: []interface{}{1, 1, 9},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, got1 := tt.group.ToSQL(nil)
+			if got != tt.want {
+				t.Errorf("Group.ToSQL(nil) got = %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(got1, tt.want1) {
+				t.Errorf("Group.ToSQL(nil) got1 = %v, want %v", got1, tt.want1)
+			}
+		})
+	}
+}
+
+func TestGroup_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		group   *Group
+		wantErr error
+	}{
+		{
+			name: "valid",
+			group: NewGroup(internal.OperatorAnd, internal.OperatorOr,
+				NewEQ(internal.OperatorAnd, "col1", "val1"),
+				NewNot(internal.OperatorAnd, NewIn(internal.OperatorAnd, "col2", []interface{}{"val2"}))),
+		},
+		{
+			name: "empty in of nested group",
+			group: NewGroup(internal.OperatorAnd, internal.OperatorOr,
+				NewNot(internal.OperatorAnd, NewIn(internal.OperatorAnd, "col2", nil))),
+			wantErr: internal.ErrEmptyIn,
+		},
+		{
+			name: "unsafe column",
+			group: NewGroup(internal.OperatorAnd, internal.OperatorAnd,
+				NewGT(internal.OperatorAnd, "col1;", "val1")),
+			wantErr: internal.ErrUnsafeIdentifier,
+		},
+		{
+			name:  "nil",
+			group: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.group.Validate(nil); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -6,17 +6,20 @@ import (
 	"github.com/wwwangxc/sqlg/internal"
 )
 
-var _ internal.Expression = (*GT)(nil)
+var (
+	_ internal.Expression = (*GT)(nil)
+	_ internal.Validator  = (*GT)(nil)
+)
 
 // GT greater than expression
 type GT struct {
 	op     internal.Operator
-	column string
+	column interface{}
 	value  interface{}
 }
 
 // NewGT create greater than expression structure
-func NewGT(op internal.Operator, column string, value interface{}) *GT {
+func NewGT(op internal.Operator, column interface{}, value interface{}) *GT {
 	return &GT{
 		op:     op,
 		column: column,
@@ -25,10 +28,23 @@ func NewGT(op internal.Operator, column string, value interface{}) *GT {
 }
 
 // ToSQL return greater than expression
-func (g *GT) ToSQL() (string, []interface{}) {
+func (g *GT) ToSQL(d internal.Dialect) (string, []interface{}) {
 	if g == nil {
 		return "", nil
 	}
 
-	return fmt.Sprintf("%s %s>?", g.op, internal.SafeName(g.column)), []interface{}{g.value}
+	return fmt.Sprintf("%s %s>?", g.op, internal.SafeName(d, g.column)), []interface{}{g.value}
+}
+
+// Validate the column and value of greater than expression
+func (g *GT) Validate(d internal.Dialect) error {
+	if g == nil {
+		return nil
+	}
+
+	if err := internal.ValidateName(d, g.column); err != nil {
+		return err
+	}
+
+	return internal.ValidateValues(g.value)
 }
@@ -6,17 +6,20 @@ import (
 	"github.com/wwwangxc/sqlg/internal"
 )
 
-var _ internal.Expression = (*GTE)(nil)
+var (
+	_ internal.Expression = (*GTE)(nil)
+	_ internal.Validator  = (*GTE)(nil)
+)
 
 // GTE greater than or equal expression
 type GTE struct {
 	op     internal.Operator
-	column string
+	column interface{}
 	value  interface{}
 }
 
 // NewGTE create greater than or equal expression structure
-func NewGTE(op internal.Operator, column string, value interface{}) *GTE {
+func NewGTE(op internal.Operator, column interface{}, value interface{}) *GTE {
 	return &GTE{
 		op:     op,
 		column: column,
@@ -25,10 +28,23 @@ func NewGTE(op internal.Operator, column string, value interface{}) *GTE {
 }
 
 // ToSQL return greater than or equal expression
-func (g *GTE) ToSQL() (string, []interface{}) {
+func (g *GTE) ToSQL(d internal.Dialect) (string, []interface{}) {
 	if g == nil {
 		return "", nil
 	}
 
-	return fmt.Sprintf("%s %s>=?", g.op, internal.SafeName(g.column)), []interface{}{g.value}
+	return fmt.Sprintf("%s %s>=?", g.op, internal.SafeName(d, g.column)), []interface{}{g.value}
+}
+
+// Validate the column and value of greater than or equal expression
+func (g *GTE) Validate(d internal.Dialect) error {
+	if g == nil {
+		return nil
+	}
+
+	if err := internal.ValidateName(d, g.column); err != nil {
+		return err
+	}
+
+	return internal.ValidateValues(g.value)
 }
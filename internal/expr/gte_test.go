@@ -47,12 +47,12 @@ func TestGTE_ToSQL(t *testing.T) {
 				column: tt.fields.column,
 				value:  tt.fields.value,
 			}
-			got, got1 := g.ToSQL()
+			got, got1 := g.ToSQL(nil)
 			if got != tt.want {
-				t.Errorf("GTE.ToSQL() got = %v, want %v", got, tt.want)
+				t.Errorf("GTE.ToSQL(nil) got = %v, want %v", got, tt.want)
 			}
 			if !reflect.DeepEqual(got1, tt.want1) {
-				t.Errorf("GTE.ToSQL() got1 = %v, want %v", got1, tt.want1)
+				t.Errorf("GTE.ToSQL(nil) got1 = %v, want %v", got1, tt.want1)
 			}
 		})
 	}
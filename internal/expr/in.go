@@ -7,18 +7,21 @@ import (
 	"github.com/wwwangxc/sqlg/internal"
 )
 
-var _ internal.Expression = (*In)(nil)
+var (
+	_ internal.Expression = (*In)(nil)
+	_ internal.Validator  = (*In)(nil)
+)
 
 // In expression
 type In struct {
 	op     internal.Operator
-	column string
+	column interface{}
 	values []interface{}
 	isNot  bool
 }
 
 // NewIn create in expression structure
-func NewIn(op internal.Operator, column string, values []interface{}) *In {
+func NewIn(op internal.Operator, column interface{}, values []interface{}) *In {
 	return &In{
 		op:     op,
 		column: column,
@@ -28,7 +31,7 @@ func NewIn(op internal.Operator, column string, values []interface{}) *In {
 }
 
 // NewNIn create not in expression structure
-func NewNIn(op internal.Operator, column string, values []interface{}) *In {
+func NewNIn(op internal.Operator, column interface{}, values []interface{}) *In {
 	return &In{
 		op:     op,
 		column: column,
@@ -38,7 +41,7 @@ func NewNIn(op internal.Operator, column string, values []interface{}) *In {
 }
 
 // ToSQL return in expression
-func (i *In) ToSQL() (string, []interface{}) {
+func (i *In) ToSQL(d internal.Dialect) (string, []interface{}) {
 	if i == nil {
 		return "", nil
 	}
@@ -53,5 +56,23 @@ func (i *In) ToSQL() (string, []interface{}) {
 		symbol = "NOT "
 	}
 
-	return fmt.Sprintf("%s %s %sIN (%s)", i.op, internal.SafeName(i.column), symbol, placeholder), i.values
+	return fmt.Sprintf("%s %s %sIN (%s)", i.op, internal.SafeName(d, i.column), symbol, placeholder), i.values
+}
+
+// Validate the column and values of in expression, the empty values will
+// generate invalid statement
+func (i *In) Validate(d internal.Dialect) error {
+	if i == nil {
+		return nil
+	}
+
+	if len(i.values) == 0 {
+		return internal.ErrEmptyIn
+	}
+
+	if err := internal.ValidateName(d, i.column); err != nil {
+		return err
+	}
+
+	return internal.ValidateValues(i.values...)
 }
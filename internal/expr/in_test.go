@@ -1,6 +1,7 @@
 package expr
 
 import (
+	"errors"
 	"reflect"
 	"testing"
 
@@ -73,12 +74,60 @@ func TestIn_ToSQL(t *testing.T) {
 				values: tt.fields.values,
 				isNot:  tt.fields.isNot,
 			}
-			got, got1 := i.ToSQL()
+			got, got1 := i.ToSQL(nil)
 			if got != tt.want {
-				t.Errorf("In.ToSQL() got = %v, want %v", got, tt.want)
+				t.Errorf("In.ToSQL(nil) got = %v, want %v", got, tt.want)
 			}
 			if !reflect.DeepEqual(got1, tt.want1) {
-				t.Errorf("In.ToSQL() got1 = %v, want %v", got1, tt.want1)
+				t.Errorf("In.ToSQL(nil) got1 = %v, want %v", got1, tt.want1)
+			}
+		})
+	}
+}
+
+func TestIn_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      *In
+		wantErr error
+	}{
+		{
+			name: "valid",
+			in:   NewIn(internal.OperatorAnd, "col", []interface{}{"val1"}),
+		},
+		{
+			name:    "empty values",
+			in:      NewIn(internal.OperatorAnd, "col", []interface{}{}),
+			wantErr: internal.ErrEmptyIn,
+		},
+		{
+			name:    "empty values of not in",
+			in:      NewNIn(internal.OperatorOr, "col", nil),
+			wantErr: internal.ErrEmptyIn,
+		},
+		{
+			name:    "unsafe column",
+			in:      NewIn(internal.OperatorAnd, "col;", []interface{}{"val1"}),
+			wantErr: internal.ErrUnsafeIdentifier,
+		},
+		{
+			name:    "raw value mismatch args",
+			in:      NewIn(internal.OperatorAnd, "col", []interface{}{"val1", internal.NewRaw("? + ?", 1)}),
+			wantErr: internal.ErrPlaceholderMismatch,
+		},
+		{
+			name: "raw value",
+			in:   NewIn(internal.OperatorAnd, "col", []interface{}{internal.NewRaw("`parent_id` + ?", 1)}),
+		},
+		{
+			name: "nil",
+			in:   nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.in.Validate(nil); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
 	}
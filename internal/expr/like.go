@@ -6,19 +6,22 @@ import (
 	"github.com/wwwangxc/sqlg/internal"
 )
 
-var _ internal.Expression = (*Like)(nil)
+var (
+	_ internal.Expression = (*Like)(nil)
+	_ internal.Validator  = (*Like)(nil)
+)
 
 // Like expression
 type Like struct {
 	op     internal.Operator
-	column string
+	column interface{}
 	format string
 	value  interface{}
 	isNot  bool
 }
 
 // NewLike create like expression structure
-func NewLike(op internal.Operator, column, format string, value interface{}) *Like {
+func NewLike(op internal.Operator, column interface{}, format string, value interface{}) *Like {
 	return &Like{
 		op:     op,
 		column: column,
@@ -29,7 +32,7 @@ func NewLike(op internal.Operator, column, format string, value interface{}) *Li
 }
 
 // NewNLike create not like expression structure
-func NewNLike(op internal.Operator, column, format string, value interface{}) *Like {
+func NewNLike(op internal.Operator, column interface{}, format string, value interface{}) *Like {
 	return &Like{
 		op:     op,
 		column: column,
@@ -40,7 +43,7 @@ func NewNLike(op internal.Operator, column, format string, value interface{}) *L
 }
 
 // ToSQL return like expression
-func (l *Like) ToSQL() (string, []interface{}) {
+func (l *Like) ToSQL(d internal.Dialect) (string, []interface{}) {
 	if l == nil {
 		return "", nil
 	}
@@ -50,6 +53,20 @@ func (l *Like) ToSQL() (string, []interface{}) {
 		symbol = "NOT "
 	}
 
-	return fmt.Sprintf("%s %s %sLIKE ?", l.op, internal.SafeName(l.column), symbol),
+	return fmt.Sprintf("%s %s %sLIKE ?", l.op, internal.SafeName(d, l.column), symbol),
 		[]interface{}{fmt.Sprintf(l.format, l.value)}
 }
+
+// Validate the column and value of like expression, the value of trusted
+// expression is formatted into the pattern as text, which is rejected
+func (l *Like) Validate(d internal.Dialect) error {
+	if l == nil {
+		return nil
+	}
+
+	if _, ok := l.value.(internal.Trusted); ok {
+		return fmt.Errorf("%w: %v", internal.ErrTrustedLikeValue, l.value)
+	}
+
+	return internal.ValidateName(d, l.column)
+}
@@ -1,6 +1,7 @@
 package expr
 
 import (
+	"errors"
 	"reflect"
 	"testing"
 
@@ -175,12 +176,55 @@ func TestLike_ToSQL(t *testing.T) {
 				value:  tt.fields.value,
 				isNot:  tt.fields.isNot,
 			}
-			got, got1 := l.ToSQL()
+			got, got1 := l.ToSQL(nil)
 			if got != tt.want {
-				t.Errorf("Like.ToSQL() got = %v, want %v", got, tt.want)
+				t.Errorf("Like.ToSQL(nil) got = %v, want %v", got, tt.want)
 			}
 			if !reflect.DeepEqual(got1, tt.want1) {
-				t.Errorf("Like.ToSQL() got1 = %v, want %v", got1, tt.want1)
+				t.Errorf("Like.ToSQL(nil) got1 = %v, want %v", got1, tt.want1)
+			}
+		})
+	}
+}
+
+func TestLike_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		like    *Like
+		wantErr error
+	}{
+		{
+			name: "valid",
+			like: NewLike(internal.OperatorAnd, "col", "%%%s%%", "val"),
+		},
+		{
+			name:    "unsafe column",
+			like:    NewLike(internal.OperatorAnd, "col;", "%%%s%%", "val"),
+			wantErr: internal.ErrUnsafeIdentifier,
+		},
+		{
+			name:    "trusted value",
+			like:    NewLike(internal.OperatorAnd, "col", "%%%s%%", internal.NewRaw("`name`")),
+			wantErr: internal.ErrTrustedLikeValue,
+		},
+		{
+			name:    "trusted value of not like",
+			like:    NewNLike(internal.OperatorOr, "col", "%s%%", internal.NewRaw("`name`")),
+			wantErr: internal.ErrTrustedLikeValue,
+		},
+		{
+			name: "raw column",
+			like: NewLike(internal.OperatorAnd, internal.NewRaw("LOWER(`name`)"), "%%%s%%", "val"),
+		},
+		{
+			name: "nil",
+			like: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.like.Validate(nil); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
 	}
@@ -6,17 +6,20 @@ import (
 	"github.com/wwwangxc/sqlg/internal"
 )
 
-var _ internal.Expression = (*LT)(nil)
+var (
+	_ internal.Expression = (*LT)(nil)
+	_ internal.Validator  = (*LT)(nil)
+)
 
 // LT less than expression
 type LT struct {
 	op     internal.Operator
-	column string
+	column interface{}
 	value  interface{}
 }
 
 // NewLT create less than expression structure
-func NewLT(op internal.Operator, column string, value interface{}) *LT {
+func NewLT(op internal.Operator, column interface{}, value interface{}) *LT {
 	return &LT{
 		op:     op,
 		column: column,
@@ -25,10 +28,23 @@ func NewLT(op internal.Operator, column string, value interface{}) *LT {
 }
 
 // ToSQL return less than expression
-func (l *LT) ToSQL() (string, []interface{}) {
+func (l *LT) ToSQL(d internal.Dialect) (string, []interface{}) {
 	if l == nil {
 		return "", nil
 	}
 
-	return fmt.Sprintf("%s %s<?", l.op, internal.SafeName(l.column)), []interface{}{l.value}
+	return fmt.Sprintf("%s %s<?", l.op, internal.SafeName(d, l.column)), []interface{}{l.value}
+}
+
+// Validate the column and value of less than expression
+func (l *LT) Validate(d internal.Dialect) error {
+	if l == nil {
+		return nil
+	}
+
+	if err := internal.ValidateName(d, l.column); err != nil {
+		return err
+	}
+
+	return internal.ValidateValues(l.value)
 }
@@ -47,12 +47,12 @@ func TestLT_ToSQL(t *testing.T) {
 				column: tt.fields.column,
 				value:  tt.fields.value,
 			}
-			got, got1 := l.ToSQL()
+			got, got1 := l.ToSQL(nil)
 			if got != tt.want {
-				t.Errorf("LT.ToSQL() got = %v, want %v", got, tt.want)
+				t.Errorf("LT.ToSQL(nil) got = %v, want %v", got, tt.want)
 			}
 			if !reflect.DeepEqual(got1, tt.want1) {
-				t.Errorf("LT.ToSQL() got1 = %v, want %v", got1, tt.want1)
+				t.Errorf("LT.ToSQL(nil) got1 = %v, want %v", got1, tt.want1)
 			}
 		})
 	}
@@ -6,17 +6,20 @@ import (
 	"github.com/wwwangxc/sqlg/internal"
 )
 
-var _ internal.Expression = (*LTE)(nil)
+var (
+	_ internal.Expression = (*LTE)(nil)
+	_ internal.Validator  = (*LTE)(nil)
+)
 
 // LTE less than or equal expression
 type LTE struct {
 	op     internal.Operator
-	column string
+	column interface{}
 	value  interface{}
 }
 
 // NewLTE create less than or equal expression structure
-func NewLTE(op internal.Operator, column string, value interface{}) *LTE {
+func NewLTE(op internal.Operator, column interface{}, value interface{}) *LTE {
 	return &LTE{
 		op:     op,
 		column: column,
@@ -25,10 +28,23 @@ func NewLTE(op internal.Operator, column string, value interface{}) *LTE {
 }
 
 // ToSQL return less than or equal expression
-func (l *LTE) ToSQL() (string, []interface{}) {
+func (l *LTE) ToSQL(d internal.Dialect) (string, []interface{}) {
 	if l == nil {
 		return "", nil
 	}
 
-	return fmt.Sprintf("%s %s<=?", l.op, internal.SafeName(l.column)), []interface{}{l.value}
+	return fmt.Sprintf("%s %s<=?", l.op, internal.SafeName(d, l.column)), []interface{}{l.value}
+}
+
+// Validate the column and value of less than or equal expression
+func (l *LTE) Validate(d internal.Dialect) error {
+	if l == nil {
+		return nil
+	}
+
+	if err := internal.ValidateName(d, l.column); err != nil {
+		return err
+	}
+
+	return internal.ValidateValues(l.value)
 }
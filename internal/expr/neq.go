@@ -6,17 +6,20 @@ import (
 	"github.com/wwwangxc/sqlg/internal"
 )
 
-var _ internal.Expression = (*NEQ)(nil)
+var (
+	_ internal.Expression = (*NEQ)(nil)
+	_ internal.Validator  = (*NEQ)(nil)
+)
 
 // NEQ not equal expression
 type NEQ struct {
 	op     internal.Operator
-	column string
+	column interface{}
 	value  interface{}
 }
 
 // NewNEQ create not equal expression structure
-func NewNEQ(op internal.Operator, column string, value interface{}) *NEQ {
+func NewNEQ(op internal.Operator, column interface{}, value interface{}) *NEQ {
 	return &NEQ{
 		op:     op,
 		column: column,
@@ -25,10 +28,23 @@ func NewNEQ(op internal.Operator, column string, value interface{}) *NEQ {
 }
 
 // ToSQL return not equal expression
-func (n *NEQ) ToSQL() (string, []interface{}) {
+func (n *NEQ) ToSQL(d internal.Dialect) (string, []interface{}) {
 	if n == nil {
 		return "", nil
 	}
 
-	return fmt.Sprintf("%s %s!=?", n.op, internal.SafeName(n.column)), []interface{}{n.value}
+	return fmt.Sprintf("%s %s!=?", n.op, internal.SafeName(d, n.column)), []interface{}{n.value}
+}
+
+// Validate the column and value of not equal expression
+func (n *NEQ) Validate(d internal.Dialect) error {
+	if n == nil {
+		return nil
+	}
+
+	if err := internal.ValidateName(d, n.column); err != nil {
+		return err
+	}
+
+	return internal.ValidateValues(n.value)
 }
@@ -47,12 +47,12 @@ func TestNEQ_ToSQL(t *testing.T) {
 				column: tt.fields.column,
 				value:  tt.fields.value,
 			}
-			got, got1 := n.ToSQL()
+			got, got1 := n.ToSQL(nil)
 			if got != tt.want {
-				t.Errorf("NEQ.ToSQL() got = %v, want %v", got, tt.want)
+				t.Errorf("NEQ.ToSQL(nil) got = %v, want %v", got, tt.want)
 			}
 			if !reflect.DeepEqual(got1, tt.want1) {
-				t.Errorf("NEQ.ToSQL() got1 = %v, want %v", got1, tt.want1)
+				t.Errorf("NEQ.ToSQL(nil) got1 = %v, want %v", got1, tt.want1)
 			}
 		})
 	}
@@ -11,12 +11,12 @@ var _ (internal.Expression) = (*Null)(nil)
 // Null is null expression
 type Null struct {
 	op     internal.Operator
-	column string
+	column interface{}
 	isNot  bool
 }
 
 // NewNull create is null expression structure
-func NewNull(op internal.Operator, column string) *Null {
+func NewNull(op internal.Operator, column interface{}) *Null {
 	return &Null{
 		op:     op,
 		column: column,
@@ -25,7 +25,7 @@ func NewNull(op internal.Operator, column string) *Null {
 }
 
 // NewNNull create is not null expression structure
-func NewNNull(op internal.Operator, column string) *Null {
+func NewNNull(op internal.Operator, column interface{}) *Null {
 	return &Null{
 		op:     op,
 		column: column,
@@ -34,7 +34,7 @@ func NewNNull(op internal.Operator, column string) *Null {
 }
 
 // ToSQL return is null expression
-func (n *Null) ToSQL() (string, []interface{}) {
+func (n *Null) ToSQL(d internal.Dialect) (string, []interface{}) {
 	if n == nil {
 		return "", nil
 	}
@@ -44,5 +44,14 @@ func (n *Null) ToSQL() (string, []interface{}) {
 		symbol = "NOT "
 	}
 
-	return fmt.Sprintf("%s %s IS %sNULL", n.op, internal.SafeName(n.column), symbol), nil
+	return fmt.Sprintf("%s %s IS %sNULL", n.op, internal.SafeName(d, n.column), symbol), nil
+}
+
+// Validate the column of null expression
+func (n *Null) Validate(d internal.Dialect) error {
+	if n == nil {
+		return nil
+	}
+
+	return internal.ValidateName(d, n.column)
 }
@@ -67,12 +67,12 @@ func TestNull_ToSQL(t *testing.T) {
 				column: tt.fields.column,
 				isNot:  tt.fields.isNot,
 			}
-			got, got1 := n.ToSQL()
+			got, got1 := n.ToSQL(nil)
 			if got != tt.want {
-				t.Errorf("Null.ToSQL() got = %v, want %v", got, tt.want)
+				t.Errorf("Null.ToSQL(nil) got = %v, want %v", got, tt.want)
 			}
 			if !reflect.DeepEqual(got1, tt.want1) {
-				t.Errorf("Null.ToSQL() got1 = %v, want %v", got1, tt.want1)
+				t.Errorf("Null.ToSQL(nil) got1 = %v, want %v", got1, tt.want1)
 			}
 		})
 	}
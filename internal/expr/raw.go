@@ -0,0 +1,47 @@
+package expr
+
+import (
+	"fmt"
+
+	"github.com/wwwangxc/sqlg/internal"
+)
+
+var (
+	_ internal.Expression = (*Raw)(nil)
+	_ internal.Validator  = (*Raw)(nil)
+)
+
+// Raw expression, the trusted expression is used as condition
+type Raw struct {
+	op  internal.Operator
+	sql interface{}
+}
+
+// NewRaw create raw expression structure, the sql can be trusted expression
+// or string
+func NewRaw(op internal.Operator, sql interface{}) *Raw {
+	return &Raw{
+		op:  op,
+		sql: sql,
+	}
+}
+
+// ToSQL return raw expression, the args of trusted expression are returned
+// as values
+func (r *Raw) ToSQL(d internal.Dialect) (string, []interface{}) {
+	if r == nil {
+		return "", nil
+	}
+
+	sql, values := internal.SafeNameWithArgs(d, r.sql)
+	return fmt.Sprintf("%s (%s)", r.op, sql), values
+}
+
+// Validate the placeholders and args of raw expression
+func (r *Raw) Validate(d internal.Dialect) error {
+	if r == nil {
+		return nil
+	}
+
+	return internal.ValidateExpr(d, r.sql)
+}
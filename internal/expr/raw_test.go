@@ -0,0 +1,128 @@
+package expr
+
+import (
+	"database/sql"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/wwwangxc/sqlg/internal"
+)
+
+func TestRaw_ToSQL(t *testing.T) {
+	type fields struct {
+		op  internal.Operator
+		sql interface{}
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   string
+		want1  []interface{}
+	}{
+		{
+			name: "and raw",
+			fields: fields{
+				op:  internal.OperatorAnd,
+				sql: internal.NewRaw("JSON_CONTAINS(`tags`, ?)", "go"),
+			},
+			want:  "AND (JSON_CONTAINS(`tags`, ?))",
+			want1: []interface{}{"go"},
+		},
+		{
+			name: "or raw",
+			fields: fields{
+				op:  internal.OperatorOr,
+				sql: internal.NewRaw("`a`=? OR `b` BETWEEN ? AND ?", 1, int8(2), uint(3)),
+			},
+			want:  "OR (`a`=? OR `b` BETWEEN ? AND ?)",
+			want1: []interface{}{1, int8(2), uint(3)},
+		},
+		{
+			name: "valuer args",
+			fields: fields{
+				op:  internal.OperatorAnd,
+				sql: internal.NewRaw("`a`=? AND `b`=?", sql.NullInt64{Int64: 5, Valid: true}, sql.NullString{}),
+			},
+			want:  "AND (`a`=? AND `b`=?)",
+			want1: []interface{}{sql.NullInt64{Int64: 5, Valid: true}, sql.NullString{}},
+		},
+		{
+			name: "nested raw",
+			fields: fields{
+				op:  internal.OperatorAnd,
+				sql: internal.NewRaw("? > ?", internal.NewRaw("DATE(`a`)"), internal.NewRaw("DATE(?)", "2022-01-01")),
+			},
+			want:  "AND (DATE(`a`) > DATE(?))",
+			want1: []interface{}{"2022-01-01"},
+		},
+		{
+			name: "plain expression",
+			fields: fields{
+				op:  internal.OperatorAnd,
+				sql: "`a` IS NOT NULL",
+			},
+			want:  "AND (`a` IS NOT NULL)",
+			want1: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Raw{
+				op:  tt.fields.op,
+				sql: tt.fields.sql,
+			}
+			got, got1 := r.ToSQL(nil)
+			if got != tt.want {
+				t.Errorf("Raw.ToSQL(nil) got = %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(got1, tt.want1) {
+				t.Errorf("Raw.ToSQL(nil) got1 = %v, want %v", got1, tt.want1)
+			}
+		})
+	}
+}
+
+func TestRaw_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     *Raw
+		wantErr error
+	}{
+		{
+			name: "valid",
+			raw:  NewRaw(internal.OperatorAnd, internal.NewRaw("DATE(`created_at`)=?", "2022-01-01")),
+		},
+		{
+			name:    "missing args",
+			raw:     NewRaw(internal.OperatorAnd, internal.NewRaw("`a`=? AND `b`=?", 1)),
+			wantErr: internal.ErrPlaceholderMismatch,
+		},
+		{
+			name:    "extra args",
+			raw:     NewRaw(internal.OperatorAnd, internal.NewRaw("`a`='?'", 1)),
+			wantErr: internal.ErrPlaceholderMismatch,
+		},
+		{
+			name: "arg kept as it is",
+			raw:  NewRaw(internal.OperatorAnd, internal.NewRaw("`a`=? AND `b`=?", struct{}{}, [4]byte{1})),
+		},
+		{
+			name:    "unsupported type",
+			raw:     NewRaw(internal.OperatorAnd, 1),
+			wantErr: internal.ErrUnsafeIdentifier,
+		},
+		{
+			name:    "unsafe expression",
+			raw:     NewRaw(internal.OperatorAnd, "1=1; DROP TABLE user"),
+			wantErr: internal.ErrUnsafeIdentifier,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.raw.Validate(nil); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
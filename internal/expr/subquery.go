@@ -0,0 +1,56 @@
+package expr
+
+import (
+	"fmt"
+
+	"github.com/wwwangxc/sqlg/internal"
+)
+
+var (
+	_ internal.Expression = (*Subquery)(nil)
+	_ internal.Validator  = (*Subquery)(nil)
+)
+
+// Subquery expression, compare the column with the result of subquery
+type Subquery struct {
+	op     internal.Operator
+	column interface{}
+	symbol string
+	query  internal.Query
+}
+
+// NewSubquery create subquery expression structure
+//
+// The symbol is placed between the column and the subquery, such as "=",
+// " IN ". The column can be empty for the symbol like "EXISTS ".
+func NewSubquery(op internal.Operator, column interface{}, symbol string, query internal.Query) *Subquery {
+	return &Subquery{
+		op:     op,
+		column: column,
+		symbol: symbol,
+		query:  query,
+	}
+}
+
+// ToSQL return subquery expression
+func (s *Subquery) ToSQL(d internal.Dialect) (string, []interface{}) {
+	if s == nil || s.query == nil {
+		return "", nil
+	}
+
+	sql, params := s.query.ToSQL(d)
+	return fmt.Sprintf("%s %s%s(%s)", s.op, internal.SafeName(d, s.column), s.symbol, sql), params
+}
+
+// Validate the column and query of subquery expression
+func (s *Subquery) Validate(d internal.Dialect) error {
+	if s == nil {
+		return nil
+	}
+
+	if err := internal.ValidateName(d, s.column); err != nil {
+		return err
+	}
+
+	return internal.Validate(d, s.query)
+}
@@ -0,0 +1,82 @@
+package expr
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/wwwangxc/sqlg/internal"
+)
+
+type query struct {
+	sql    string
+	params []interface{}
+}
+
+func (q *query) ToSQL(d internal.Dialect) (string, []interface{}) {
+	return q.sql, q.params
+}
+
+func TestSubquery_ToSQL(t *testing.T) {
+	type fields struct {
+		op     internal.Operator
+		column string
+		symbol string
+		query  internal.Query
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		want   string
+		want1  []interface{}
+	}{
+		{
+			name: "and eq",
+			fields: fields{
+				op:     internal.OperatorAnd,
+				column: "col",
+				symbol: "=",
+				query:  &query{sql: "SELECT MAX(`col`) FROM `table_name` WHERE `col1`=?", params: []interface{}{"val"}},
+			},
+			want:  "AND `col`=(SELECT MAX(`col`) FROM `table_name` WHERE `col1`=?)",
+			want1: []interface{}{"val"},
+		},
+		{
+			name: "or in",
+			fields: fields{
+				op:     internal.OperatorOr,
+				column: "col",
+				symbol: " IN ",
+				query:  &query{sql: "SELECT `col` FROM `table_name` WHERE `col1`=?", params: []interface{}{"val"}},
+			},
+			want:  "OR `col` IN (SELECT `col` FROM `table_name` WHERE `col1`=?)",
+			want1: []interface{}{"val"},
+		},
+		{
+			name: "and not exists",
+			fields: fields{
+				op:     internal.OperatorAnd,
+				symbol: "NOT EXISTS ",
+				query:  &query{sql: "SELECT * FROM `table_name`"},
+			},
+			want:  "AND NOT EXISTS (SELECT * FROM `table_name`)",
+			want1: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Subquery{
+				op:     tt.fields.op,
+				column: tt.fields.column,
+				symbol: tt.fields.symbol,
+				query:  tt.fields.query,
+			}
+			got, got1 := s.ToSQL(nil)
+			if got != tt.want {
+				t.Errorf("Subquery.ToSQL() got = %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(got1, tt.want1) {
+				t.Errorf("Subquery.ToSQL() got1 = %v, want %v", got1, tt.want1)
+			}
+		})
+	}
+}
@@ -3,5 +3,5 @@ package internal
 // Expression of condition
 type Expression interface {
 	// ToSQL return sql expression
-	ToSQL() (string, []interface{})
+	ToSQL(d Dialect) (string, []interface{})
 }
@@ -6,26 +6,76 @@ import (
 )
 
 // SafeNames of table、column、index
-func SafeNames(names []string) []string {
+func SafeNames(d Dialect, names []string) []string {
 	var cooked []string
 	for _, v := range names {
-		cooked = append(cooked, SafeName(v))
+		cooked = append(cooked, SafeName(d, v))
 	}
 
 	return cooked
 }
 
-// SafeName of table、column、index
-func SafeName(column string) string {
+// SafeNamesWithArgs return the safe names and the args of the trusted
+// expressions in textual order
+func SafeNamesWithArgs(d Dialect, names []interface{}) ([]string, []interface{}) {
+	var cooked []string
+	var args []interface{}
+	for _, v := range names {
+		name, a := SafeNameWithArgs(d, v)
+		cooked = append(cooked, name)
+		args = append(args, a...)
+	}
+
+	return cooked, args
+}
+
+// SafeName of table、column、index, the args of trusted expression are dropped
+func SafeName(d Dialect, column interface{}) string {
+	name, _ := SafeNameWithArgs(d, column)
+	return name
+}
+
+// SafeNameWithArgs return the safe name of table、column、index and the args
+// of trusted expression
+//
+// The table-qualified name will be quoted separately, such as `t`.`col`.
+// The name will be quoted with backtick when the dialect is nil.
+//
+// The trusted expression and the name contains ( or space are treated as
+// expression, the backtick quoted identifiers of expression will be requoted
+// with the dialect. In strict mode, only the trusted expression is treated as
+// expression. The name of other type is formatted as string.
+func SafeNameWithArgs(d Dialect, name interface{}) (string, []interface{}) {
+	if t, ok := name.(Trusted); ok {
+		sql, args := t.RawSQL()
+		return Requote(d, sql), args
+	}
+
+	column, ok := name.(string)
+	if !ok {
+		column = fmt.Sprint(name)
+	}
+
 	switch {
-	case column == "":
-		return ""
-	case column == "*",
-		strings.Contains(column, "("),
+	case column == "", column == "*":
+		return column, nil
+	case Strict(d):
+	case strings.Contains(column, "("),
 		strings.Contains(column, " "):
-		return column
+		return Requote(d, column), nil
 	default:
 	}
 
-	return fmt.Sprintf("`%s`", strings.Trim(column, "`"))
+	parts := strings.Split(column, ".")
+	for i, v := range parts {
+		switch {
+		case v == "*":
+		case d == nil:
+			parts[i] = Quote(v, "`", "`")
+		default:
+			parts[i] = d.Quote(v)
+		}
+	}
+
+	return strings.Join(parts, "."), nil
 }
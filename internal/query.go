@@ -0,0 +1,7 @@
+package internal
+
+// Query which can be embedded into statement as subquery
+type Query interface {
+	// ToSQL return sql statement of query
+	ToSQL(d Dialect) (string, []interface{})
+}
@@ -0,0 +1,190 @@
+package internal
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+)
+
+// ErrPlaceholderMismatch the count of placeholders of raw expression is
+// different from the args, or the args can not be bound
+var ErrPlaceholderMismatch = errors.New("sqlg: placeholders mismatch args of raw expression")
+
+// Trusted expression, which is written into the statement without quoting,
+// the backtick quoted identifiers will be requoted with the dialect
+type Trusted interface {
+	// RawSQL return the sql and the args of ? placeholders
+	RawSQL() (string, []interface{})
+}
+
+var _ Trusted = Raw{}
+
+// Raw the trusted expression of sql and args
+type Raw struct {
+	sql  string
+	args []interface{}
+}
+
+// NewRaw create the trusted expression, the placeholder whose arg is trusted
+// expression is replaced with the expression, and its args are merged in
+// textual order
+func NewRaw(sql string, args ...interface{}) Raw {
+	sql, args = mergeRaw(sql, args)
+	return Raw{
+		sql:  sql,
+		args: args,
+	}
+}
+
+// RawSQL return the sql and the args of ? placeholders
+func (r Raw) RawSQL() (string, []interface{}) {
+	return r.sql, r.args
+}
+
+// String return the sql of trusted expression
+func (r Raw) String() string {
+	return r.sql
+}
+
+// ValidateRaw return error when the count of placeholders of trusted
+// expression is different from the args
+func ValidateRaw(t Trusted) error {
+	sql, args := t.RawSQL()
+	if n := CountPlaceholders(sql); n != len(args) {
+		return fmt.Errorf("%w: %q has %d placeholders, got %d args", ErrPlaceholderMismatch, sql, n, len(args))
+	}
+
+	return nil
+}
+
+// BindRaw replace the ? placeholders whose params are trusted expressions
+// with the expressions requoted by dialect, the args of them are merged with
+// the params in textual order
+func BindRaw(d Dialect, sql string, params []interface{}) (string, []interface{}) {
+	if !hasRaw(params) {
+		return sql, params
+	}
+
+	buffer := bytes.NewBuffer(make([]byte, 0, len(sql)))
+	bound := make([]interface{}, 0, len(params))
+	eachPlaceholder(sql, func(text string, placeholder bool) {
+		if !placeholder || len(params) == 0 {
+			buffer.WriteString(text)
+			return
+		}
+
+		param := params[0]
+		params = params[1:]
+		if t, ok := param.(Trusted); ok {
+			s, a := t.RawSQL()
+			buffer.WriteString(Requote(d, s))
+			bound = append(bound, a...)
+			return
+		}
+
+		buffer.WriteString(text)
+		bound = append(bound, param)
+	})
+
+	return buffer.String(), append(bound, params...)
+}
+
+// ValidateValues return error when the value is trusted expression whose
+// placeholders mismatch the args
+func ValidateValues(values ...interface{}) error {
+	for _, v := range values {
+		t, ok := v.(Trusted)
+		if !ok {
+			continue
+		}
+
+		if err := ValidateRaw(t); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func hasRaw(params []interface{}) bool {
+	for _, v := range params {
+		if _, ok := v.(Trusted); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CountPlaceholders return the count of ? placeholders, the ? in quoted
+// string or identifier will be ignored
+func CountPlaceholders(sql string) int {
+	var n int
+	eachPlaceholder(sql, func(text string, placeholder bool) {
+		if placeholder {
+			n++
+		}
+	})
+
+	return n
+}
+
+// mergeRaw replace the placeholder whose arg is trusted expression with the
+// expression, and merge its args with the args of sql in textual order
+func mergeRaw(sql string, args []interface{}) (string, []interface{}) {
+	if !hasRaw(args) {
+		return sql, args
+	}
+
+	buffer := bytes.NewBuffer(make([]byte, 0, len(sql)))
+	merged := make([]interface{}, 0, len(args))
+	eachPlaceholder(sql, func(text string, placeholder bool) {
+		if !placeholder || len(args) == 0 {
+			buffer.WriteString(text)
+			return
+		}
+
+		arg := args[0]
+		args = args[1:]
+		if t, ok := arg.(Trusted); ok {
+			s, a := t.RawSQL()
+			buffer.WriteString(s)
+			merged = append(merged, a...)
+			return
+		}
+
+		buffer.WriteString(text)
+		merged = append(merged, arg)
+	})
+
+	return buffer.String(), append(merged, args...)
+}
+
+// eachPlaceholder split sql into the texts and the ? placeholders, the ? in
+// quoted string or identifier is treated as text
+func eachPlaceholder(sql string, f func(text string, placeholder bool)) {
+	var quote byte
+	var start int
+	for i := 0; i < len(sql); i++ {
+		c := sql[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'', c == '"', c == '`':
+			quote = c
+		case c == '?':
+			if start < i {
+				f(sql[start:i], false)
+			}
+
+			f("?", true)
+			start = i + 1
+		}
+	}
+
+	if start < len(sql) {
+		f(sql[start:], false)
+	}
+}
@@ -0,0 +1,283 @@
+package internal
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// Options of the db tag
+//
+// EXP:
+//
+//	`db:"id,pk,autoincr"`
+//	`db:"nickname,omitempty"`
+//	`db:"created_at,readonly"`
+//	`db:"addr_,prefix"`
+const (
+	// tagAutoIncr the auto increment column, which is skipped by insert
+	// statement when it's zero value
+	tagAutoIncr = "autoincr"
+
+	// tagOmitEmpty the column is skipped by insert statement when it's zero
+	// value
+	tagOmitEmpty = "omitempty"
+
+	// tagReadOnly the column is maintained by database, which is always
+	// skipped by insert statement
+	tagReadOnly = "readonly"
+
+	// tagPK the column of primary key, the autoincr columns are primary key
+	// when there is no pk column
+	tagPK = "pk"
+
+	// tagPrefix the fields of nested structure are flattened with the columns
+	// prefixed by the name of tag
+	tagPrefix = "prefix"
+)
+
+// Field of the tagged structure
+type Field struct {
+	// Index of the field, which is nested when the field is flattened from
+	// the embedded or nested structure
+	Index []int
+
+	// Column of the field, prefixed by the nested structure
+	Column string
+
+	// Expr the select expression of expr tag
+	Expr string
+
+	AutoIncr  bool
+	OmitEmpty bool
+	ReadOnly  bool
+	PK        bool
+
+	depth int
+}
+
+// structInfos the metadata of structure, which is cached by type
+var structInfos sync.Map
+
+// StructInfo the reflection metadata of structure, which is shared by the
+// struct mapping APIs
+type StructInfo struct {
+	// Fields with db tag
+	Fields []Field
+
+	// Columns of select statement, the column of field with expr tag is
+	// replaced with the trusted expression aliased to the column
+	Columns []interface{}
+
+	// PrimaryKey the fields with pk option, or the autoincr fields when
+	// there is no pk field
+	PrimaryKey []Field
+
+	// columns indexed by column name
+	columns map[string]int
+
+	// selects the select columns quoted by dialect
+	selects sync.Map
+}
+
+// GetStructInfo return the cached metadata of structure type
+func GetStructInfo(t reflect.Type) *StructInfo {
+	if v, ok := structInfos.Load(t); ok {
+		return v.(*StructInfo)
+	}
+
+	v, _ := structInfos.LoadOrStore(t, newStructInfo(t))
+	return v.(*StructInfo)
+}
+
+func newStructInfo(t reflect.Type) *StructInfo {
+	info := &StructInfo{Fields: StructFields(t)}
+	info.Columns = make([]interface{}, 0, len(info.Fields))
+	info.columns = make(map[string]int, len(info.Fields))
+	for i, v := range info.Fields {
+		var column interface{} = v.Column
+
+		// the expr tag is written by developer and trusted as expression, which
+		// is aliased to the column to be mapped back by name
+		if v.Expr != "" {
+			column = NewRaw(fmt.Sprintf("%s AS %s", v.Expr, Quote(v.Column, "`", "`")))
+		}
+
+		info.Columns = append(info.Columns, column)
+		info.columns[v.Column] = i
+
+		if v.PK {
+			info.PrimaryKey = append(info.PrimaryKey, v)
+		}
+	}
+
+	if len(info.PrimaryKey) > 0 {
+		return info
+	}
+
+	for _, v := range info.Fields {
+		if v.AutoIncr {
+			info.PrimaryKey = append(info.PrimaryKey, v)
+		}
+	}
+
+	return info
+}
+
+// Field return the field of column, false is returned when there is no field
+// of the column
+func (s *StructInfo) Field(column string) (Field, bool) {
+	i, ok := s.columns[column]
+	if !ok {
+		return Field{}, false
+	}
+
+	return s.Fields[i], true
+}
+
+// SelectColumns return the select columns quoted by dialect, which is
+// joined as trusted expression
+func (s *StructInfo) SelectColumns(d Dialect) []interface{} {
+	if len(s.Columns) == 0 {
+		return nil
+	}
+
+	if v, ok := s.selects.Load(d); ok {
+		return v.([]interface{})
+	}
+
+	columns, args := SafeNamesWithArgs(d, s.Columns)
+	v, _ := s.selects.LoadOrStore(d, []interface{}{NewRaw(strings.Join(columns, ", "), args...)})
+	return v.([]interface{})
+}
+
+// StructFields return the exported fields with db tag of structure without
+// cache
+//
+// The unexported fields are skipped, since they can not be read or assigned.
+// The fields of anonymous embedded structure without db tag are flattened,
+// and the fields of nested structure with the prefix option are flattened
+// with the columns prefixed. The field of shallower depth wins when the
+// columns are duplicated, just like the field selector of Go.
+func StructFields(t reflect.Type) []Field {
+	fields := appendFields(nil, t, nil, "", map[reflect.Type]bool{})
+
+	depth := map[string]int{}
+	for _, v := range fields {
+		if d, ok := depth[v.Column]; !ok || v.depth < d {
+			depth[v.Column] = v.depth
+		}
+	}
+
+	result := make([]Field, 0, len(fields))
+	for _, v := range fields {
+		if d, ok := depth[v.Column]; ok && d == v.depth {
+			result = append(result, v)
+			delete(depth, v.Column)
+		}
+	}
+
+	return result
+}
+
+// appendFields append the exported fields of structure, the fields of
+// anonymous embedded structure are promoted even if it's unexported
+func appendFields(fields []Field, t reflect.Type, index []int, prefix string, visited map[reflect.Type]bool) []Field {
+	if visited[t] {
+		return fields
+	}
+
+	visited[t] = true
+	defer delete(visited, t)
+
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		column, options := parseTag(sf.Tag.Get("db"))
+		if column == "-" {
+			continue
+		}
+
+		fieldIndex := make([]int, len(index), len(index)+1)
+		copy(fieldIndex, index)
+		fieldIndex = append(fieldIndex, i)
+
+		if nested := StructType(sf.Type); nested != nil {
+			switch {
+			case sf.Anonymous && column == "":
+				fields = appendFields(fields, nested, fieldIndex, prefix, visited)
+				continue
+			case sf.PkgPath != "":
+				continue
+			case hasOption(options, tagPrefix):
+				fields = appendFields(fields, nested, fieldIndex, prefix+column, visited)
+				continue
+			}
+		}
+
+		if column == "" || sf.PkgPath != "" {
+			continue
+		}
+
+		fields = append(fields, Field{
+			Index:     fieldIndex,
+			Column:    prefix + column,
+			Expr:      sf.Tag.Get("expr"),
+			AutoIncr:  hasOption(options, tagAutoIncr),
+			OmitEmpty: hasOption(options, tagOmitEmpty),
+			ReadOnly:  hasOption(options, tagReadOnly),
+			PK:        hasOption(options, tagPK),
+			depth:     len(index),
+		})
+	}
+
+	return fields
+}
+
+// StructType return the type of structure or pointer to structure, otherwise
+// return nil
+func StructType(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	return t
+}
+
+func hasOption(options []string, option string) bool {
+	for _, v := range options {
+		if strings.TrimSpace(v) == option {
+			return true
+		}
+	}
+
+	return false
+}
+
+// FieldByIndex return the nested field of structure, false is returned when
+// the embedded pointer is nil
+func FieldByIndex(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+
+			v = v.Elem()
+		}
+
+		v = v.Field(x)
+	}
+
+	return v, true
+}
+
+// parseTag return the column and the options of db tag
+func parseTag(tag string) (string, []string) {
+	items := strings.Split(tag, ",")
+	return strings.TrimSpace(items[0]), items[1:]
+}
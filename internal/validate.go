@@ -0,0 +1,112 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	// ErrEmptyIn the values of IN expression is empty
+	ErrEmptyIn = errors.New("sqlg: empty values of IN expression")
+
+	// ErrUnsafeIdentifier the identifier contains statement terminator,
+	// comment or NUL character
+	ErrUnsafeIdentifier = errors.New("sqlg: unsafe identifier")
+
+	// ErrTrustedLikeValue the value of LIKE expression is trusted expression,
+	// which can not be formatted into the pattern
+	ErrTrustedLikeValue = errors.New("sqlg: trusted expression as value of LIKE expression")
+)
+
+// identifierPattern the grammar of identifier in strict mode, such as col,
+// t.col, t.*
+var identifierPattern = regexp.MustCompile(`^([A-Za-z_][A-Za-z0-9_$]*\.)*([A-Za-z_][A-Za-z0-9_$]*|\*)$`)
+
+// Validator can be validated before generating the statement
+type Validator interface {
+	// Validate return error when the statement generated with the dialect
+	// is invalid
+	Validate(d Dialect) error
+}
+
+// Validate the expression or query, return nil when it's not validator
+func Validate(d Dialect, v interface{}) error {
+	validator, ok := v.(Validator)
+	if !ok {
+		return nil
+	}
+
+	return validator.Validate(d)
+}
+
+// ValidateNames of table、column、index
+func ValidateNames(d Dialect, names ...string) error {
+	for _, v := range names {
+		if err := ValidateName(d, v); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ValidateName of table、column、index, the trusted expression with args is
+// invalid, because the args can not be bound
+func ValidateName(d Dialect, name interface{}) error {
+	if err := ValidateExpr(d, name); err != nil {
+		return err
+	}
+
+	if sql, args := SafeNameWithArgs(d, name); len(args) > 0 {
+		return fmt.Errorf("%w: args of %q can not be bound", ErrPlaceholderMismatch, sql)
+	}
+
+	return nil
+}
+
+// ValidateExprs of select column、ORDER BY、GROUP BY
+func ValidateExprs(d Dialect, names ...interface{}) error {
+	for _, v := range names {
+		if err := ValidateExpr(d, v); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ValidateExpr of select column、ORDER BY、GROUP BY, which can be the trusted
+// expression with args
+//
+// The trusted expression is safe when the placeholders match the args, and
+// the name must be string otherwise. In strict mode, the name must be empty
+// or match the grammar of identifier, otherwise the name contains ;、--、/*、
+// */ or NUL character is unsafe.
+func ValidateExpr(d Dialect, name interface{}) error {
+	if t, ok := name.(Trusted); ok {
+		return ValidateRaw(t)
+	}
+
+	s, ok := name.(string)
+	if !ok {
+		return fmt.Errorf("%w: %v of type %T", ErrUnsafeIdentifier, name, name)
+	}
+
+	if Strict(d) {
+		if s != "" && !identifierPattern.MatchString(s) {
+			return fmt.Errorf("%w: %q", ErrUnsafeIdentifier, s)
+		}
+
+		return nil
+	}
+
+	for _, v := range []string{";", "--", "/*", "*/", "\x00"} {
+		if strings.Contains(s, v) {
+			return fmt.Errorf("%w: %q", ErrUnsafeIdentifier, s)
+		}
+	}
+
+	return nil
+}
@@ -1,8 +1,6 @@
 package sqlg
 
 import (
-	"fmt"
-
 	"github.com/wwwangxc/sqlg/internal"
 	"github.com/wwwangxc/sqlg/internal/expr"
 )
@@ -10,14 +8,169 @@ import (
 // Option is optional for the SQL generator
 type Option func(*Options)
 
+// WithDialect set the dialect of SQL statement, default is MySQL
+func WithDialect(dialect Dialect) Option {
+	return func(o *Options) {
+		if dialect == nil {
+			return
+		}
+
+		o.dialect = dialect
+	}
+}
+
+// WithStrictIdentifier enable strict identifier mode, which is the default
+// mode
+//
+// In strict mode, the table、column and index names are always quoted as
+// identifier with the embedded quote symbol escaped, the expressions must be
+// wrapped by Raw. The Build methods return ErrUnsafeIdentifier when the name
+// does not match the grammar of identifier, such as col, t.col, t.*
+func WithStrictIdentifier() Option {
+	return func(o *Options) {
+		o.strict = true
+	}
+}
+
+// WithLooseIdentifier disable strict identifier mode
+//
+// In loose mode, the name contains ( or space is written as expression
+// without quoting, which must never come from the user input. The strict mode
+// of outer statement is still inherited by the subquery.
+func WithLooseIdentifier() Option {
+	return func(o *Options) {
+		o.strict = false
+	}
+}
+
+// WithColumns set the default columns of select statement
+//
+// The default columns are selected when no column is specified, such as the
+// generator is embedded into another statement.
+func WithColumns(columns ...interface{}) Option {
+	return func(o *Options) {
+		o.columns = append(o.columns, columns...)
+	}
+}
+
+// WithCTE append common table expression, the query is the select statement
+// of generator, it's rejected by the update and delete statements of
+// ClickHouse
+//
+// EXP:
+//
+//	WITH ${name} AS (${query})
+func WithCTE(name string, query *Generator) Option {
+	return func(o *Options) {
+		if name == "" || query == nil {
+			return
+		}
+
+		o.ctes = append(o.ctes, cte{
+			name:    name,
+			queries: []internal.Query{newSubquery(query, nil)},
+		})
+	}
+}
+
+// WithRecursiveCTE append recursive common table expression, the anchor and
+// recursive query are the select statements of generator
+//
+// EXP:
+//
+//	WITH RECURSIVE ${name} AS (${anchor} UNION ALL ${recursive})
+func WithRecursiveCTE(name string, anchor, recursive *Generator) Option {
+	return func(o *Options) {
+		if name == "" || anchor == nil || recursive == nil {
+			return
+		}
+
+		o.ctes = append(o.ctes, cte{
+			name:      name,
+			recursive: true,
+			queries:   []internal.Query{newSubquery(anchor, nil), newSubquery(recursive, nil)},
+		})
+	}
+}
+
+// WithAlias set alias of the table for generate select statement
+//
+// EXP:
+//
+//	FROM ${table} AS ${alias}
+func WithAlias(alias string) Option {
+	return func(o *Options) {
+		o.alias = alias
+	}
+}
+
+// WithJoin append INNER JOIN clause, the expressions of ON condition are
+// joined with AND
+//
+// EXP:
+//
+//	INNER JOIN ${table} AS ${alias} ON ${expr1} AND ${expr2}
+func WithJoin(table, alias string, on *CompExpr) Option {
+	return withJoin("INNER JOIN", table, alias, on)
+}
+
+// WithLeftJoin append LEFT JOIN clause, the expressions of ON condition are
+// joined with AND
+//
+// EXP:
+//
+//	LEFT JOIN ${table} AS ${alias} ON ${expr1} AND ${expr2}
+func WithLeftJoin(table, alias string, on *CompExpr) Option {
+	return withJoin("LEFT JOIN", table, alias, on)
+}
+
+// WithRightJoin append RIGHT JOIN clause, the expressions of ON condition are
+// joined with AND
+//
+// EXP:
+//
+//	RIGHT JOIN ${table} AS ${alias} ON ${expr1} AND ${expr2}
+func WithRightJoin(table, alias string, on *CompExpr) Option {
+	return withJoin("RIGHT JOIN", table, alias, on)
+}
+
+// WithCrossJoin append CROSS JOIN clause
+//
+// EXP:
+//
+//	CROSS JOIN ${table} AS ${alias}
+func WithCrossJoin(table, alias string) Option {
+	return withJoin("CROSS JOIN", table, alias, nil)
+}
+
+func withJoin(kind, table, alias string, m *CompExpr) Option {
+	return func(o *Options) {
+		if table == "" {
+			return
+		}
+
+		on := &internal.Condition{}
+		m.each(func(column interface{}, expr Expr) {
+			on.Append(buildExpr(expr, internal.OperatorAnd, column))
+		})
+
+		o.joins = append(o.joins, join{
+			kind:  kind,
+			table: table,
+			alias: alias,
+			on:    on,
+		})
+	}
+}
+
 // WithAnd append AND expression into the condition
 //
 // EXP:
 //
 //	AND ${expr}
-func WithAnd(column string, expr Expr) Option {
+func WithAnd(column interface{}, expr Expr) Option {
 	return func(o *Options) {
-		o.where.Append(expr(internal.OperatorAnd, column))
+		o.where.Append(buildExpr(expr, internal.OperatorAnd, column))
 	}
 }
 
@@ -26,9 +179,35 @@ func WithAnd(column string, expr Expr) Option {
 // EXP:
 //
 //	OR ${expr}
-func WithOr(column string, expr Expr) Option {
+func WithOr(column interface{}, expr Expr) Option {
 	return func(o *Options) {
-		o.where.Append(expr(internal.OperatorOr, column))
+		o.where.Append(buildExpr(expr, internal.OperatorOr, column))
+	}
+}
+
+// WithPrewhere append AND expression into the PREWHERE condition
+//
+// PREWHERE is supported by the select statement of ClickHouse only, the
+// PREWHERE condition will be folded into the WHERE condition by other
+// dialects and by the update, delete and conditional insert statements.
+//
+// EXP:
+//
+//	PREWHERE ${expr1} AND ${expr2}
+func WithPrewhere(column interface{}, expr Expr) Option {
+	return func(o *Options) {
+		o.prewhere.Append(buildExpr(expr, internal.OperatorAnd, column))
+	}
+}
+
+// WithOrPrewhere append OR expression into the PREWHERE condition
+//
+// EXP:
+//
+//	PREWHERE ${expr1} OR ${expr2}
+func WithOrPrewhere(column interface{}, expr Expr) Option {
+	return func(o *Options) {
+		o.prewhere.Append(buildExpr(expr, internal.OperatorOr, column))
 	}
 }
 
@@ -44,8 +223,8 @@ func WithAndExprs(m *CompExpr) Option {
 		}
 
 		exprs := make([]internal.Expression, 0, m.size())
-		m.each(func(column string, expr Expr) {
-			exprs = append(exprs, expr(internal.OperatorOr, column))
+		m.each(func(column interface{}, expr Expr) {
+			exprs = append(exprs, buildExpr(expr, internal.OperatorOr, column))
 		})
 
 		o.where.Append(expr.NewCompound(internal.OperatorAnd, exprs...))
@@ -64,14 +243,42 @@ func WithOrExprs(m *CompExpr) Option {
 		}
 
 		exprs := make([]internal.Expression, 0, m.size())
-		m.each(func(column string, expr Expr) {
-			exprs = append(exprs, expr(internal.OperatorAnd, column))
+		m.each(func(column interface{}, expr Expr) {
+			exprs = append(exprs, buildExpr(expr, internal.OperatorAnd, column))
 		})
 
 		o.where.Append(expr.NewCompound(internal.OperatorOr, exprs...))
 	}
 }
 
+// WithAndCond append the boolean expression tree with AND
+//
+// EXP:
+//
+//	WithAndCond(Or(Cond("a", EQ(1)), And(Cond("b", GT(2)), Cond("b", LT(5)))))
+//	AND (${a}=? OR ${b}>? AND ${b}<?)
+func WithAndCond(c *Condition) Option {
+	return func(o *Options) {
+		if !c.empty() {
+			o.where.Append(c.build(internal.OperatorAnd))
+		}
+	}
+}
+
+// WithOrCond append the boolean expression tree with OR
+//
+// EXP:
+//
+//	WithOrCond(And(Cond("a", EQ(1)), Not(Cond("b", EQ(2)))))
+//	OR (${a}=? AND NOT (${b}=?))
+func WithOrCond(c *Condition) Option {
+	return func(o *Options) {
+		if !c.empty() {
+			o.where.Append(c.build(internal.OperatorOr))
+		}
+	}
+}
+
 // WithExists append exists expression
 //
 // EXP:
@@ -84,8 +291,8 @@ func WithExists(table string, m *CompExpr) Option {
 		}
 
 		exprs := make([]internal.Expression, 0, m.size())
-		m.each(func(column string, expr Expr) {
-			exprs = append(exprs, expr(internal.OperatorAnd, column))
+		m.each(func(column interface{}, expr Expr) {
+			exprs = append(exprs, buildExpr(expr, internal.OperatorAnd, column))
 		})
 
 		o.where.Append(expr.NewExists(internal.OperatorAnd, table, exprs...))
@@ -104,8 +311,8 @@ func WithNExists(table string, m *CompExpr) Option {
 		}
 
 		exprs := make([]internal.Expression, 0, m.size())
-		m.each(func(column string, expr Expr) {
-			exprs = append(exprs, expr(internal.OperatorAnd, column))
+		m.each(func(column interface{}, expr Expr) {
+			exprs = append(exprs, buildExpr(expr, internal.OperatorAnd, column))
 		})
 
 		o.where.Append(expr.NewNExists(internal.OperatorAnd, table, exprs...))
@@ -124,8 +331,8 @@ func WithOrExists(table string, m *CompExpr) Option {
 		}
 
 		exprs := make([]internal.Expression, 0, m.size())
-		m.each(func(column string, expr Expr) {
-			exprs = append(exprs, expr(internal.OperatorAnd, column))
+		m.each(func(column interface{}, expr Expr) {
+			exprs = append(exprs, buildExpr(expr, internal.OperatorAnd, column))
 		})
 
 		o.where.Append(expr.NewExists(internal.OperatorOr, table, exprs...))
@@ -144,8 +351,8 @@ func WithOrNExists(table string, m *CompExpr) Option {
 		}
 
 		exprs := make([]internal.Expression, 0, m.size())
-		m.each(func(column string, expr Expr) {
-			exprs = append(exprs, expr(internal.OperatorAnd, column))
+		m.each(func(column interface{}, expr Expr) {
+			exprs = append(exprs, buildExpr(expr, internal.OperatorAnd, column))
 		})
 
 		o.where.Append(expr.NewNExists(internal.OperatorOr, table, exprs...))
@@ -157,20 +364,86 @@ func WithOrNExists(table string, m *CompExpr) Option {
 // EXP:
 //
 //	GROUP BY ${column1}, ${column2}
-func WithGroupBy(columns ...string) Option {
+func WithGroupBy(columns ...interface{}) Option {
 	return func(o *Options) {
 		o.groupBy = append(o.groupBy, columns...)
 	}
 }
 
+// WithHaving append AND expression into the having condition
+//
+// EXP:
+//
+//	HAVING ${expr1} AND ${expr2}
+func WithHaving(column interface{}, expr Expr) Option {
+	return func(o *Options) {
+		o.having.Append(buildExpr(expr, internal.OperatorAnd, column))
+	}
+}
+
+// WithOrHaving append OR expression into the having condition
+//
+// EXP:
+//
+//	HAVING ${expr1} OR ${expr2}
+func WithOrHaving(column interface{}, expr Expr) Option {
+	return func(o *Options) {
+		o.having.Append(buildExpr(expr, internal.OperatorOr, column))
+	}
+}
+
+// WithHavingCond append the boolean expression tree into the having
+// condition with AND
+//
+// EXP:
+//
+//	HAVING ${expr} AND (${cond1} OR ${cond2})
+func WithHavingCond(c *Condition) Option {
+	return func(o *Options) {
+		if !c.empty() {
+			o.having.Append(c.build(internal.OperatorAnd))
+		}
+	}
+}
+
+// WithOrHavingCond append the boolean expression tree into the having
+// condition with OR
+//
+// EXP:
+//
+//	HAVING ${expr} OR ${cond1} AND ${cond2}
+func WithOrHavingCond(c *Condition) Option {
+	return func(o *Options) {
+		if !c.empty() {
+			o.having.Append(c.build(internal.OperatorOr))
+		}
+	}
+}
+
+// WithWindow append named window definition, which can be referenced by
+// WindowFunc.OverWindow
+//
+// EXP:
+//
+//	WINDOW ${name} AS (${window})
+func WithWindow(name string, w *Window) Option {
+	return func(o *Options) {
+		if name == "" || w == nil {
+			return
+		}
+
+		o.windows = append(o.windows, window{name: name, spec: w})
+	}
+}
+
 // WithOrderBy append order by condition
 //
 // EXP:
 //
 //	ORDER BY ${column} ASC
-func WithOrderBy(column string) Option {
+func WithOrderBy(column interface{}) Option {
 	return func(o *Options) {
-		o.orderBy = append(o.orderBy, fmt.Sprintf("%s ASC", internal.SafeName(column)))
+		o.orderBy = append(o.orderBy, orderBy{column: column})
 	}
 }
 
@@ -179,9 +452,9 @@ func WithOrderBy(column string) Option {
 // EXP:
 //
 //	ORDER BY ${column} DESC
-func WithOrderByDESC(column string) Option {
+func WithOrderByDESC(column interface{}) Option {
 	return func(o *Options) {
-		o.orderBy = append(o.orderBy, fmt.Sprintf("%s DESC", internal.SafeName(column)))
+		o.orderBy = append(o.orderBy, orderBy{column: column, desc: true})
 	}
 }
 
@@ -190,6 +463,8 @@ func WithOrderByDESC(column string) Option {
 // EXP:
 //
 //	LIMIT ${limit}
+//	TOP (${limit})
+//	FETCH NEXT ${limit} ROWS ONLY
 func WithLimit(limit uint32) Option {
 	return func(o *Options) {
 		o.limit = limit
@@ -201,6 +476,7 @@ func WithLimit(limit uint32) Option {
 // EXP:
 //
 //	OFFSET ${offset}
+//	OFFSET ${offset} ROWS
 func WithOffset(offset uint32) Option {
 	return func(o *Options) {
 		o.offset = offset
@@ -212,12 +488,50 @@ func WithOffset(offset uint32) Option {
 // EXP:
 //
 //	FORCE INDEX(${index})
+//	INDEXED BY ${index}
+//	WITH (INDEX(${index}))
 func ForceIndex(index string) Option {
 	return func(o *Options) {
 		o.forceIndex = index
 	}
 }
 
+// WithFinal set FINAL modifier of the table, supported by ClickHouse only
+//
+// EXP:
+//
+//	FROM ${table} FINAL
+func WithFinal() Option {
+	return func(o *Options) {
+		o.final = true
+	}
+}
+
+// WithSample set SAMPLE clause of the table, supported by ClickHouse only
+//
+// The ratio between 0 and 1 means the fraction of data, and greater than 1
+// means the approximate number of rows.
+//
+// EXP:
+//
+//	FROM ${table} SAMPLE ${ratio}
+func WithSample(ratio float64) Option {
+	return func(o *Options) {
+		o.sample = ratio
+	}
+}
+
+// WithArrayJoin append ARRAY JOIN clause, supported by ClickHouse only
+//
+// EXP:
+//
+//	ARRAY JOIN ${column1}, ${column2}
+func WithArrayJoin(columns ...string) Option {
+	return func(o *Options) {
+		o.arrayJoin = append(o.arrayJoin, columns...)
+	}
+}
+
 // OnDuplicateKeyUpdate for generate insert statment
 //
 // EXP:
@@ -229,11 +543,88 @@ func OnDuplicateKeyUpdate(assExpr *AssExpr) Option {
 	}
 }
 
+// OnConflict set the conflict target for generate insert statement
+//
+// Used by the dialects which resolve the conflict with ON CONFLICT clause,
+// such as Postgres. The insert statement will do nothing on conflict when
+// OnDuplicateKeyUpdate is not set.
+//
+// EXP:
+//
+//	ON CONFLICT (${column1}, ${column2}) DO UPDATE SET ${column}=${value}
+//	ON CONFLICT (${column1}, ${column2}) DO NOTHING
+func OnConflict(columns ...string) Option {
+	return func(o *Options) {
+		o.onConflict = append(o.onConflict, columns...)
+	}
+}
+
+// OnConflictUpdate set the columns to the values proposed for insertion on conflict
+//
+// EXP:
+//
+//	ON DUPLICATE KEY UPDATE ${column}=VALUES(${column})
+//	ON CONFLICT (${target}) DO UPDATE SET ${column}=excluded.${column}
+func OnConflictUpdate(columns ...string) Option {
+	return func(o *Options) {
+		o.onConflictUpdate = append(o.onConflictUpdate, columns...)
+	}
+}
+
+// InsertOrReplace replace the existing row on conflict for generate insert statement
+//
+// Postgres has no REPLACE statement, all inserted columns except the
+// conflict target will be updated on conflict instead. The REPLACE statement
+// of MySQL can not be combined with OnDuplicateKeyUpdate or OnConflictUpdate.
+//
+// EXP:
+//
+//	REPLACE INTO ...
+//	INSERT OR REPLACE INTO ...
+func InsertOrReplace() Option {
+	return func(o *Options) {
+		o.insertMode = insertModeReplace
+	}
+}
+
+// InsertOrIgnore ignore the inserted row on conflict for generate insert statement
+//
+// EXP:
+//
+//	INSERT IGNORE INTO ...
+//	INSERT OR IGNORE INTO ...
+//	INSERT INTO ... ON CONFLICT DO NOTHING
+func InsertOrIgnore() Option {
+	return func(o *Options) {
+		o.insertMode = insertModeIgnore
+	}
+}
+
+// Returning return the columns of the rows affected by the statement, return
+// all columns when columns is empty
+//
+// MySQL does not support returning the affected rows, it will be ignored.
+//
+// EXP:
+//
+//	RETURNING ${column1}, ${column2}
+//	OUTPUT inserted.${column1}, inserted.${column2}
+func Returning(columns ...string) Option {
+	return func(o *Options) {
+		if len(columns) == 0 {
+			columns = allColumns
+		}
+
+		o.returning = append(o.returning, columns...)
+	}
+}
+
 // ForUpdate set for update symbol
 //
 // EXP:
 //
 //	FOR UPDATE
+//	WITH (UPDLOCK, ROWLOCK)
 func ForUpdate() Option {
 	return func(o *Options) {
 		o.forUpdate = true
@@ -6,18 +6,66 @@ import (
 	"strings"
 
 	"github.com/wwwangxc/sqlg/internal"
+	"github.com/wwwangxc/sqlg/internal/expr"
 )
 
 // Options of SQL generator
 type Options struct {
+	dialect              Dialect
+	ctes                 []cte
+	columns              []interface{}
+	alias                string
+	joins                []join
 	where                *internal.Condition
-	orderBy              []string
-	groupBy              []string
+	prewhere             *internal.Condition
+	orderBy              []orderBy
+	groupBy              []interface{}
+	having               *internal.Condition
+	windows              []window
 	limit                uint32
 	offset               uint32
 	forceIndex           string
 	onDuplicateKeyUpdate *AssExpr
+	onConflict           []string
+	onConflictUpdate     []string
+	insertMode           insertMode
+	returning            []string
 	forUpdate            bool
+	final                bool
+	sample               float64
+	arrayJoin            []string
+	strict               bool
+}
+
+type insertMode uint8
+
+const (
+	insertModeDefault insertMode = iota
+	insertModeReplace
+	insertModeIgnore
+)
+
+type cte struct {
+	name      string
+	recursive bool
+	queries   []internal.Query
+}
+
+type join struct {
+	kind  string
+	table string
+	alias string
+	on    *internal.Condition
+}
+
+type window struct {
+	name string
+	spec *Window
+}
+
+type orderBy struct {
+	column interface{}
+	desc   bool
 }
 
 func newOptions(opts ...Option) *Options {
@@ -26,25 +74,131 @@ func newOptions(opts ...Option) *Options {
 		opt(o)
 	}
 
+	if o.strict && !internal.Strict(o.dialect) {
+		o.dialect = strictDialect{o.dialect}
+	}
+
 	return o
 }
 
 func defaultOptions() *Options {
 	return &Options{
+		dialect:    MySQL,
+		strict:     true,
 		where:      &internal.Condition{},
-		orderBy:    []string{},
+		prewhere:   &internal.Condition{},
+		having:     &internal.Condition{},
+		orderBy:    []orderBy{},
 		limit:      0,
 		offset:     0,
 		forceIndex: "",
 	}
 }
 
-func (o *Options) genForceIndex() string {
-	if o == nil || o.forceIndex == "" {
+// validate the identifiers, conditions and queries of options
+func (o *Options) validate() error {
+	if o == nil {
+		return nil
+	}
+
+	exprs := make([]interface{}, 0, len(o.columns)+len(o.groupBy)+len(o.orderBy))
+	exprs = append(exprs, o.columns...)
+	exprs = append(exprs, o.groupBy...)
+	for _, v := range o.orderBy {
+		exprs = append(exprs, v.column)
+	}
+
+	for _, v := range o.windows {
+		exprs = append(exprs, v.spec.names()...)
+	}
+
+	if err := internal.ValidateExprs(o.dialect, exprs...); err != nil {
+		return err
+	}
+
+	if err := o.onDuplicateKeyUpdate.validate(o.dialect); err != nil {
+		return err
+	}
+
+	names := []string{o.alias, o.forceIndex}
+	names = append(names, o.onConflict...)
+	names = append(names, o.onConflictUpdate...)
+	names = append(names, o.returning...)
+	names = append(names, o.arrayJoin...)
+
+	for _, v := range o.windows {
+		names = append(names, v.name)
+	}
+
+	if err := internal.ValidateNames(o.dialect, names...); err != nil {
+		return err
+	}
+
+	for _, v := range o.ctes {
+		if err := internal.ValidateName(o.dialect, v.name); err != nil {
+			return err
+		}
+
+		for _, query := range v.queries {
+			if err := internal.Validate(o.dialect, query); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, v := range o.joins {
+		if err := internal.ValidateNames(o.dialect, v.table, v.alias); err != nil {
+			return err
+		}
+
+		if err := v.on.Validate(o.dialect); err != nil {
+			return err
+		}
+	}
+
+	for _, v := range []*internal.Condition{o.where, o.prewhere, o.having} {
+		if err := v.Validate(o.dialect); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateMutation return the error when the clauses of update or delete
+// statement are not supported by dialect
+func (o *Options) validateMutation() error {
+	if o == nil {
+		return nil
+	}
+
+	return o.dialect.validateMutation(o)
+}
+
+// validateInsert return the error when the clauses of insert statement are
+// not supported by dialect
+func (o *Options) validateInsert() error {
+	if o == nil {
+		return nil
+	}
+
+	return o.dialect.validateInsert(o)
+}
+
+func (o *Options) genTableHints() string {
+	if o == nil {
 		return ""
 	}
 
-	return fmt.Sprintf("FORCE INDEX (%s)", internal.SafeName(o.forceIndex))
+	return o.dialect.genTableHints(o)
+}
+
+func (o *Options) genTop() string {
+	if o == nil || o.limit == 0 {
+		return ""
+	}
+
+	return o.dialect.genTop(o.limit, o.offset)
 }
 
 func (o *Options) genWhere() (string, []interface{}) {
@@ -52,24 +206,169 @@ func (o *Options) genWhere() (string, []interface{}) {
 		return "", nil
 	}
 
-	sql, values := o.where.ToSQL()
+	sql, values := o.where.ToSQL(o.dialect)
 	return fmt.Sprintf("WHERE %s", sql), values
 }
 
-func (o *Options) genGroupBy() string {
-	if o == nil || len(o.groupBy) == 0 {
+// genWith return WITH clause, the queries of the recursive common table
+// expression are combined with UNION ALL
+//
+// EXP:
+//
+//	WITH RECURSIVE ${name} AS (${anchor} UNION ALL ${recursive}), ${name} AS (${query})
+func (o *Options) genWith() (string, []interface{}) {
+	if o == nil || len(o.ctes) == 0 {
+		return "", nil
+	}
+
+	var recursive bool
+	var params []interface{}
+	ctes := make([]string, 0, len(o.ctes))
+	for _, v := range o.ctes {
+		queries := make([]string, 0, len(v.queries))
+		for _, query := range v.queries {
+			sql, values := query.ToSQL(o.dialect)
+			queries = append(queries, sql)
+			params = append(params, values...)
+		}
+
+		recursive = recursive || v.recursive
+		ctes = append(ctes, fmt.Sprintf("%s AS (%s)", internal.SafeName(o.dialect, v.name), strings.Join(queries, " UNION ALL ")))
+	}
+
+	sql := "WITH"
+	if recursive {
+		sql += sqlOrEmpty(o.dialect.genRecursive())
+	}
+
+	return fmt.Sprintf("%s %s", sql, strings.Join(ctes, ", ")), params
+}
+
+func (o *Options) genAlias() string {
+	if o == nil || o.alias == "" {
 		return ""
 	}
 
-	return fmt.Sprintf("GROUP BY %s", strings.Join(internal.SafeNames(o.groupBy), ", "))
+	return fmt.Sprintf("AS %s", internal.SafeName(o.dialect, o.alias))
+}
+
+func (o *Options) genJoins() (string, []interface{}) {
+	if o == nil || len(o.joins) == 0 {
+		return "", nil
+	}
+
+	var params []interface{}
+	joins := make([]string, 0, len(o.joins))
+	for _, v := range o.joins {
+		sql := fmt.Sprintf("%s %s", v.kind, internal.SafeName(o.dialect, v.table))
+		if v.alias != "" {
+			sql = fmt.Sprintf("%s AS %s", sql, internal.SafeName(o.dialect, v.alias))
+		}
+
+		if !v.on.Empty() {
+			on, values := v.on.ToSQL(o.dialect)
+			sql = fmt.Sprintf("%s ON %s", sql, on)
+			params = append(params, values...)
+		}
+
+		joins = append(joins, sql)
+	}
+
+	return strings.Join(joins, " "), params
+}
+
+// genSelectWhere return the filter clauses of select statement
+func (o *Options) genSelectWhere() (string, []interface{}) {
+	if o == nil {
+		return "", nil
+	}
+
+	return o.dialect.genSelectWhere(o)
 }
 
-func (o *Options) genOrderBy() string {
+// genFoldedWhere return WHERE clause with the PREWHERE conditions folded in,
+// for the dialects which do not support PREWHERE clause
+//
+// EXP:
+//
+//	WHERE (${prewhere}) AND (${where})
+func (o *Options) genFoldedWhere() (string, []interface{}) {
+	if o == nil || o.prewhere.Empty() {
+		return o.genWhere()
+	}
+
+	cond := &internal.Condition{}
+	cond.Append(expr.NewCompound(internal.OperatorAnd, o.prewhere.Exprs()...))
+	if !o.where.Empty() {
+		cond.Append(expr.NewCompound(internal.OperatorAnd, o.where.Exprs()...))
+	}
+
+	sql, values := cond.ToSQL(o.dialect)
+	return fmt.Sprintf("WHERE %s", sql), values
+}
+
+func (o *Options) genPrewhere() (string, []interface{}) {
+	if o == nil || o.prewhere.Empty() {
+		return "", nil
+	}
+
+	sql, values := o.prewhere.ToSQL(o.dialect)
+	return fmt.Sprintf("PREWHERE %s", sql), values
+}
+
+func (o *Options) genGroupBy() (string, []interface{}) {
+	if o == nil || len(o.groupBy) == 0 {
+		return "", nil
+	}
+
+	columns, params := internal.SafeNamesWithArgs(o.dialect, o.groupBy)
+	return fmt.Sprintf("GROUP BY %s", strings.Join(columns, ", ")), params
+}
+
+func (o *Options) genHaving() (string, []interface{}) {
+	if o == nil || o.having.Empty() {
+		return "", nil
+	}
+
+	sql, values := o.having.ToSQL(o.dialect)
+	return fmt.Sprintf("HAVING %s", sql), values
+}
+
+func (o *Options) genWindow() (string, []interface{}) {
+	if o == nil || len(o.windows) == 0 {
+		return "", nil
+	}
+
+	var params []interface{}
+	items := make([]string, 0, len(o.windows))
+	for _, v := range o.windows {
+		spec, args := v.spec.toSQL(o.dialect)
+		items = append(items, fmt.Sprintf("%s AS (%s)", internal.SafeName(o.dialect, v.name), spec))
+		params = append(params, args...)
+	}
+
+	return fmt.Sprintf("WINDOW %s", strings.Join(items, ", ")), params
+}
+
+func (o *Options) genOrderBy() (string, []interface{}) {
 	if o == nil || len(o.orderBy) == 0 {
-		return ""
+		return "", nil
 	}
 
-	return fmt.Sprintf("ORDER BY %s", strings.Join(o.orderBy, ", "))
+	var params []interface{}
+	items := make([]string, 0, len(o.orderBy))
+	for _, v := range o.orderBy {
+		direction := "ASC"
+		if v.desc {
+			direction = "DESC"
+		}
+
+		column, args := internal.SafeNameWithArgs(o.dialect, v.column)
+		items = append(items, fmt.Sprintf("%s %s", column, direction))
+		params = append(params, args...)
+	}
+
+	return fmt.Sprintf("ORDER BY %s", strings.Join(items, ", ")), params
 }
 
 func (o *Options) genLimit() string {
@@ -88,36 +387,96 @@ func (o *Options) genOffset() string {
 	return fmt.Sprintf("OFFSET %d", o.offset)
 }
 
+func (o *Options) genPaging(top bool) string {
+	if o == nil {
+		return ""
+	}
+
+	return o.dialect.genPaging(o, top)
+}
+
 func (o *Options) genSet(assExpr *AssExpr) (string, []interface{}) {
-	if o == nil || assExpr == nil {
+	if o == nil || assExpr.empty() {
 		return "", nil
 	}
 
-	params := make([]interface{}, 0, assExpr.size())
-	buffer := bytes.NewBuffer(nil)
-	assExpr.each(func(column string, value interface{}) {
-		fmt.Fprintf(buffer, ", %s=?", internal.SafeName(column))
-		params = append(params, value)
-	})
+	set, params := o.genAssignments(assExpr)
+	return fmt.Sprintf("SET %s", set), params
+}
+
+func (o *Options) genInsertInto() string {
+	if o == nil {
+		return "INSERT INTO"
+	}
+
+	return o.dialect.genInsertInto(o.insertMode)
+}
+
+func (o *Options) genOnDuplicateKeyUpdate(columns []string) (string, []interface{}) {
+	if o == nil {
+		return "", nil
+	}
+
+	return o.dialect.genUpsert(o, columns)
+}
+
+// genUpsertAssignments return the assignments of upsert clause, the columns
+// of excluded will be assigned with the format
+func (o *Options) genUpsertAssignments(format string, excluded []string) (string, []interface{}) {
+	if o == nil {
+		return "", nil
+	}
+
+	set, params := o.genAssignments(o.onDuplicateKeyUpdate)
+	buffer := bytes.NewBufferString(set)
+	exist := map[string]bool{}
+	for _, column := range excluded {
+		if exist[column] || o.onDuplicateKeyUpdate.exist(column) {
+			continue
+		}
+		exist[column] = true
+
+		if buffer.Len() > 0 {
+			buffer.WriteString(", ")
+		}
 
-	sql := buffer.String()
-	return fmt.Sprintf("SET %s", sql[strings.Index(sql, " ")+1:]), params
+		name := internal.SafeName(o.dialect, column)
+		fmt.Fprintf(buffer, format, name, name)
+	}
+
+	return buffer.String(), params
 }
 
-func (o *Options) genOnDuplicateKeyUpdate() (string, []interface{}) {
-	if o == nil || o.onDuplicateKeyUpdate.empty() {
+func (o *Options) genAssignments(assExpr *AssExpr) (string, []interface{}) {
+	if o == nil || assExpr.empty() {
 		return "", nil
 	}
 
-	params := make([]interface{}, 0, o.onDuplicateKeyUpdate.size())
+	params := make([]interface{}, 0, assExpr.size())
 	buffer := bytes.NewBuffer(nil)
-	o.onDuplicateKeyUpdate.each(func(column string, value interface{}) {
-		fmt.Fprintf(buffer, ", %s=?", internal.SafeName(column))
+	assExpr.each(func(column string, value interface{}) {
+		if t, ok := value.(internal.Trusted); ok {
+			sql, args := t.RawSQL()
+			fmt.Fprintf(buffer, ", %s=%s", internal.SafeName(o.dialect, column), internal.Requote(o.dialect, sql))
+			params = append(params, args...)
+			return
+		}
+
+		fmt.Fprintf(buffer, ", %s=?", internal.SafeName(o.dialect, column))
 		params = append(params, value)
 	})
 
-	sql := buffer.String()
-	return fmt.Sprintf("ON DUPLICATE KEY UPDATE %s", sql[strings.Index(sql, " ")+1:]), params
+	return buffer.String()[2:], params
+}
+
+// genReturning return the OUTPUT clause and the RETURNING clause of statement,
+// the columns of OUTPUT clause are prefixed with the pseudo table
+func (o *Options) genReturning(pseudo string) (string, string) {
+	if o == nil || o.returning == nil {
+		return "", ""
+	}
+
+	return o.dialect.genReturning(o, pseudo)
 }
 
 func (o *Options) genForUpdate() string {
@@ -125,5 +484,5 @@ func (o *Options) genForUpdate() string {
 		return ""
 	}
 
-	return "FOR UPDATE"
+	return o.dialect.genForUpdate()
 }
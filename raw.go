@@ -0,0 +1,59 @@
+package sqlg
+
+import "github.com/wwwangxc/sqlg/internal"
+
+var _ internal.Trusted = RawExpr{}
+
+// RawExpr trusted expression created by Raw, Col, Func and the aggregate
+// functions, which can be used where the column or value is taken
+type RawExpr struct {
+	sql  string
+	args []interface{}
+
+	// values the expression references VALUES function created by Values,
+	// which is supported by ON DUPLICATE KEY UPDATE clause only
+	values bool
+}
+
+// Raw create the trusted expression, which can be used as the column of
+// select statement, the condition with nil Expr, the value of expression,
+// AssExpr and record, ORDER BY and GROUP BY.
+//
+// The expression is written into the statement without quoting, and the
+// backtick quoted identifiers are requoted with the dialect. The args are
+// bound to the ? placeholders of sql in order, the Build methods return
+// ErrPlaceholderMismatch when the count of placeholders is different from
+// the args. The placeholder whose arg is trusted expression is replaced with
+// the expression, the other args are bound as they are.
+//
+// EXP:
+//
+//	Raw("DATE(`created_at`)")
+//	Raw("JSON_CONTAINS(`tags`, ?)", tag)
+//	Raw("? / ?", Sum("amount"), Count("*"))
+func Raw(sql string, args ...interface{}) RawExpr {
+	var values bool
+	for _, v := range args {
+		if r, ok := v.(RawExpr); ok && r.values {
+			values = true
+		}
+	}
+
+	sql, args = internal.NewRaw(sql, args...).RawSQL()
+	return RawExpr{
+		sql:    sql,
+		args:   args,
+		values: values,
+	}
+}
+
+// RawSQL return the sql and the args of ? placeholders
+func (r RawExpr) RawSQL() (string, []interface{}) {
+	return r.sql, r.args
+}
+
+// String return the sql of expression, the identifiers are quoted with
+// backtick
+func (r RawExpr) String() string {
+	return r.sql
+}
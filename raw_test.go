@@ -0,0 +1,205 @@
+package sqlg
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestRaw(t *testing.T) {
+	ops := []Option{
+		WithAnd(Raw("DATE(`created_at`)"), EQ("2022-01-01")),
+		WithGroupBy(Raw("DATE(`created_at`)")),
+		WithOrderBy(Raw("FIELD(`status`, 'paid', 'unpaid')")),
+	}
+
+	g := NewGenerator("order", ops...)
+	gotSQL, gotParams := g.Select(Raw("DATE(`created_at`) AS `day`"), Count("*"))
+	wantSQL := "SELECT DATE(`created_at`) AS `day`, COUNT(*) FROM `order` WHERE DATE(`created_at`)=? " +
+		"GROUP BY DATE(`created_at`) ORDER BY FIELD(`status`, 'paid', 'unpaid') ASC"
+	assertSQL(t, gotSQL, wantSQL)
+	assertParams(t, gotParams, []interface{}{"2022-01-01"})
+
+	// requoted with the dialect
+	g = NewGenerator("order", append(ops, WithDialect(Postgres), WithStrictIdentifier())...)
+	gotSQL, _ = g.Select(Raw("DATE(`created_at`) AS `day`"), Count("*"))
+	wantSQL = `SELECT DATE("created_at") AS "day", COUNT(*) FROM "order" WHERE DATE("created_at")=$1 ` +
+		`GROUP BY DATE("created_at") ORDER BY FIELD("status", 'paid', 'unpaid') ASC`
+	assertSQL(t, gotSQL, wantSQL)
+
+	// nested raw
+	gotSQL, _ = NewGenerator("order").Select(Raw("? / ?", Sum("amount"), Count("*")))
+	assertSQL(t, gotSQL, "SELECT SUM(`amount`) / COUNT(*) FROM `order`")
+
+	// the expression of same column name is replaced, the trusted expression
+	// is always appended
+	m := NewCompExpr()
+	m.Put("status", EQ("paid"))
+	m.Put(Raw("DATE(`created_at`)"), GTE("2022-01-01"))
+	m.Put(Raw("DATE(`created_at`)"), LT("2022-02-01"))
+	m.Put("status", EQ("sent"))
+	gotSQL, gotParams = NewGenerator("order", WithAndExprs(m)).Select()
+	wantSQL = "SELECT * FROM `order` WHERE (`status`=? OR DATE(`created_at`)>=? OR DATE(`created_at`)<?)"
+	assertSQL(t, gotSQL, wantSQL)
+	assertParams(t, gotParams, []interface{}{"sent", "2022-01-01", "2022-02-01"})
+}
+
+func TestRaw_String(t *testing.T) {
+	// the trusted expression is readable
+	if got := fmt.Sprint(Count("*"), " ", Raw("`a` + ?", Col("b"))); got != "COUNT(*) `a` + `b`" {
+		t.Errorf("String() got = %q", got)
+	}
+
+	// the string of trusted expression is bound as value
+	gotSQL, gotParams := NewGenerator("user", WithAnd("name", EQ(Col("id").String()))).Select()
+	assertSQL(t, gotSQL, "SELECT * FROM `user` WHERE `name`=?")
+	assertParams(t, gotParams, []interface{}{"`id`"})
+
+	_, _, err := NewGenerator("user").BuildSelect(Count("*").String())
+	if !errors.Is(err, ErrUnsafeIdentifier) {
+		t.Errorf("got error dose not meet the expected\nexpected: %v\n  actual: %v", ErrUnsafeIdentifier, err)
+	}
+}
+
+func TestRaw_Args(t *testing.T) {
+	day := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	ops := []Option{
+		WithAnd("status", EQ("paid")),
+		WithAnd(Raw("JSON_CONTAINS(`tags`, ?)", "vip"), nil),
+		WithOr(Raw("DATE(`created_at`)=?", day), nil),
+		WithGroupBy(Raw("IF(`amount`>?, 'big', 'small')", 100)),
+		WithHaving(Count("*"), GT(1)),
+		WithOrderBy(Raw("FIELD(`status`, ?, ?)", "paid", "unpaid")),
+	}
+
+	g := NewGenerator("order", append(ops, WithDialect(Postgres))...)
+	gotSQL, gotParams := g.Select("id", Raw("`amount` * ? AS `tax`", 0.1))
+	wantSQL := `SELECT "id", "amount" * $1 AS "tax" FROM "order" ` +
+		`WHERE "status"=$2 AND (JSON_CONTAINS("tags", $3)) OR (DATE("created_at")=$4) ` +
+		`GROUP BY IF("amount">$5, 'big', 'small') HAVING COUNT(*)>$6 ORDER BY FIELD("status", $7, $8) ASC`
+	assertSQL(t, gotSQL, wantSQL)
+	assertParams(t, gotParams, []interface{}{0.1, "paid", "vip", day, 100, 1, "paid", "unpaid"})
+
+	// assignment
+	assExpr := NewAssExpr()
+	assExpr.Put("stock", Raw("GREATEST(`stock` - ?, ?)", 3, 0))
+	assExpr.Put("name", "foo")
+	g = NewGenerator("product", WithAnd("id", EQ(1)), WithOrderBy(Raw("FIELD(`id`, ?)", 1)), WithLimit(1))
+	gotSQL, gotParams, err := g.BuildUpdate(assExpr)
+	assertError(t, err, nil)
+	assertSQL(t, gotSQL, "UPDATE `product` SET `stock`=GREATEST(`stock` - ?, ?), `name`=? WHERE `id`=? ORDER BY FIELD(`id`, ?) ASC LIMIT 1")
+	assertParams(t, gotParams, []interface{}{3, 0, "foo", 1, 1})
+
+	// nested raw with args
+	gotSQL, gotParams = NewGenerator("order").Select(Raw("COALESCE(?, ?) + ?", Raw("MAX(`amount`) * ?", 2), 0, 1))
+	assertSQL(t, gotSQL, "SELECT COALESCE(MAX(`amount`) * ?, ?) + ? FROM `order`")
+	assertParams(t, gotParams, []interface{}{2, 0, 1})
+
+	gotSQL, gotParams = NewGenerator("order").Select(Raw("? + ? + ?", 1, Raw("SUM(`amount`) * ?", 2), 3))
+	assertSQL(t, gotSQL, "SELECT ? + SUM(`amount`) * ? + ? FROM `order`")
+	assertParams(t, gotParams, []interface{}{1, 2, 3})
+}
+
+// rawStatus the named type of raw arg
+type rawStatus int
+
+// rawValuer count the calls of Value
+type rawValuer struct {
+	calls *int
+}
+
+func (v rawValuer) Value() (driver.Value, error) {
+	*v.calls++
+	return "valued", nil
+}
+
+func TestRaw_TypedArgs(t *testing.T) {
+	var calls int
+	var nilPtr *int
+	valuer := rawValuer{calls: &calls}
+	doc := json.RawMessage(`{"a":1}`)
+	ip := [4]byte{127, 0, 0, 1}
+	tags := []string{"a", "b"}
+
+	// the args are kept as they are, the valuer is not evaluated
+	g := NewGenerator("order", WithAnd(Raw("`doc`=? AND `ip`=? AND `status`=? AND `v`=? AND `p`=?", doc, ip, rawStatus(3), valuer, nilPtr), nil))
+	gotSQL, gotParams, err := g.BuildSelect(Raw("JSON_OVERLAPS(`tags`, ?)", tags))
+	assertError(t, err, nil)
+	assertSQL(t, gotSQL, "SELECT JSON_OVERLAPS(`tags`, ?) FROM `order` WHERE (`doc`=? AND `ip`=? AND `status`=? AND `v`=? AND `p`=?)")
+	if want := []interface{}{tags, doc, ip, rawStatus(3), valuer, nilPtr}; !reflect.DeepEqual(gotParams, want) {
+		t.Errorf("got params dose not meet the expected\nexpected: %#v\n  actual: %#v", want, gotParams)
+	}
+
+	if calls != 0 {
+		t.Errorf("the valuer is evaluated %d times", calls)
+	}
+
+	// expression helpers
+	assExpr := NewAssExpr()
+	assExpr.Put("status", Func("GREATEST", Col("status"), rawStatus(1)))
+	assExpr.Put("views", Incr("views", rawStatus(2)))
+	assExpr.Put("stock", Decr("stock", valuer))
+	assExpr.Put("doc", Add(Col("doc"), doc))
+	gotSQL, gotParams, err = NewGenerator("order").BuildUpdate(assExpr)
+	assertError(t, err, nil)
+	assertSQL(t, gotSQL, "UPDATE `order` SET `status`=GREATEST(`status`, ?), `views`=`views` + ?, `stock`=`stock` - ?, `doc`=`doc` + ?")
+	if want := []interface{}{rawStatus(1), rawStatus(2), valuer, doc}; !reflect.DeepEqual(gotParams, want) {
+		t.Errorf("got params dose not meet the expected\nexpected: %#v\n  actual: %#v", want, gotParams)
+	}
+}
+
+func TestRaw_Build(t *testing.T) {
+	tests := []struct {
+		name    string
+		g       *Generator
+		columns []interface{}
+		wantErr error
+	}{
+		{
+			name:    "valid",
+			g:       NewGenerator("order", WithAnd(Raw("`a`=? OR `b`=?", 1, 2), nil)),
+			columns: []interface{}{Raw("IFNULL(`c`, ?)", 0)},
+		},
+		{
+			name:    "select column with missing args",
+			g:       NewGenerator("order"),
+			columns: []interface{}{Raw("IFNULL(`c`, ?)")},
+			wantErr: ErrPlaceholderMismatch,
+		},
+		{
+			name:    "condition with extra args",
+			g:       NewGenerator("order", WithOr(Raw("`a` IS NULL", 1), nil)),
+			wantErr: ErrPlaceholderMismatch,
+		},
+		{
+			name:    "order by with missing args",
+			g:       NewGenerator("order", WithOrderByDESC(Raw("FIELD(`a`, ?, ?)", 1))),
+			wantErr: ErrPlaceholderMismatch,
+		},
+		{
+			name:    "args can not be bound to column of expression",
+			g:       NewGenerator("order", WithAnd(Raw("DATE(?)", "now"), EQ(1))),
+			wantErr: ErrPlaceholderMismatch,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := tt.g.BuildSelect(tt.columns...)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("got error dose not meet the expected\nexpected: %v\n  actual: %v", tt.wantErr, err)
+			}
+		})
+	}
+
+	assExpr := NewAssExpr()
+	assExpr.Put("stock", Raw("`stock` - ?"))
+	_, _, err := NewGenerator("product").BuildUpdate(assExpr)
+	if !errors.Is(err, ErrPlaceholderMismatch) {
+		t.Errorf("got error dose not meet the expected\nexpected: %v\n  actual: %v", ErrPlaceholderMismatch, err)
+	}
+}
@@ -0,0 +1,95 @@
+package sqlg
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// setOperation combine the select statements of generators with set operator
+type setOperation struct {
+	operator string
+	queries  []*Generator
+}
+
+// Union combine the select statements of generators with UNION
+//
+// The returned generator can only generate select statement, the columns are
+// decided by the queries. Use With to append the ORDER BY and paging options
+// of the combined result.
+//
+// EXP:
+//
+//	${query1} UNION ${query2} ORDER BY ${column} LIMIT ${limit}
+func Union(queries ...*Generator) *Generator {
+	return newSetGenerator("UNION", queries)
+}
+
+// UnionAll combine the select statements of generators with UNION ALL
+//
+// EXP:
+//
+//	${query1} UNION ALL ${query2}
+func UnionAll(queries ...*Generator) *Generator {
+	return newSetGenerator("UNION ALL", queries)
+}
+
+// Intersect combine the select statements of generators with INTERSECT
+//
+// EXP:
+//
+//	${query1} INTERSECT ${query2}
+func Intersect(queries ...*Generator) *Generator {
+	return newSetGenerator("INTERSECT", queries)
+}
+
+// Except combine the select statements of generators with EXCEPT
+//
+// EXP:
+//
+//	${query1} EXCEPT ${query2}
+func Except(queries ...*Generator) *Generator {
+	return newSetGenerator("EXCEPT", queries)
+}
+
+func newSetGenerator(operator string, queries []*Generator) *Generator {
+	return &Generator{
+		setOp: &setOperation{
+			operator: operator,
+			queries:  queries,
+		},
+		opts: newOptions(),
+	}
+}
+
+// genSetOperation return the select statement of set operation
+//
+// The member query which has its own ORDER BY, paging clause or set
+// operation is parenthesized.
+func (g *Generator) genSetOperation() (string, []interface{}) {
+	with, params := g.opts.genWith()
+	queries := make([]string, 0, len(g.setOp.queries))
+	for _, v := range g.setOp.queries {
+		if v == nil {
+			continue
+		}
+
+		query := v.withDialect(g.opts.dialect)
+		sql, values := query.genSelect()
+		if query.setOp != nil || len(query.opts.orderBy) > 0 || query.opts.limit > 0 || query.opts.offset > 0 {
+			sql = g.opts.dialect.genSetMember(sql)
+		}
+
+		queries = append(queries, sql)
+		params = append(params, values...)
+	}
+
+	orderBy, orderByParams := g.opts.genOrderBy()
+	params = append(params, orderByParams...)
+
+	sql := bytes.NewBufferString(strings.TrimSpace(with + " " + strings.Join(queries, fmt.Sprintf(" %s ", g.setOp.operator))))
+	sql.WriteString(sqlOrEmpty(orderBy))
+	sql.WriteString(sqlOrEmpty(g.opts.genPaging(false)))
+
+	return sql.String(), params
+}
@@ -0,0 +1,62 @@
+package sqlg
+
+import "testing"
+
+func TestSetOperation(t *testing.T) {
+	g1 := NewGenerator("user", WithColumns("id", "name"), WithAnd("age", GT(18)))
+	g2 := NewGenerator("admin", WithColumns("id", "name"), WithAnd("level", EQ(1)), WithOrderByDESC("id"), WithLimit(10))
+	g3 := NewGenerator("guest", WithColumns("id", "name"), WithAnd("banned", EQ(true)))
+
+	// UNION
+	g := Union(g1, g2).With(WithOrderBy("name"), WithLimit(20), WithOffset(40))
+	gotSQL, gotParams := g.Select()
+	wantSQL := "SELECT `id`, `name` FROM `user` WHERE `age`>? " +
+		"UNION (SELECT `id`, `name` FROM `admin` WHERE `level`=? ORDER BY `id` DESC LIMIT 10) " +
+		"ORDER BY `name` ASC LIMIT 20 OFFSET 40"
+	wantParams := []interface{}{18, 1}
+	assertSQL(t, gotSQL, wantSQL)
+	assertParams(t, gotParams, wantParams)
+
+	// UNION ALL of EXCEPT
+	g = UnionAll(Except(g1, g3), g2).With(WithDialect(Postgres))
+	gotSQL, gotParams = g.Select()
+	wantSQL = `(SELECT "id", "name" FROM "user" WHERE "age">$1 EXCEPT SELECT "id", "name" FROM "guest" WHERE "banned"=$2) ` +
+		`UNION ALL (SELECT "id", "name" FROM "admin" WHERE "level"=$3 ORDER BY "id" DESC LIMIT 10)`
+	wantParams = []interface{}{18, true, 1}
+	assertSQL(t, gotSQL, wantSQL)
+	assertParams(t, gotParams, wantParams)
+
+	// INTERSECT
+	g = Intersect(g1, g2).With(WithDialect(SQLite))
+	gotSQL, gotParams = g.Select()
+	wantSQL = `SELECT "id", "name" FROM "user" WHERE "age">? ` +
+		`INTERSECT SELECT * FROM (SELECT "id", "name" FROM "admin" WHERE "level"=? ORDER BY "id" DESC LIMIT 10)`
+	wantParams = []interface{}{18, 1}
+	assertSQL(t, gotSQL, wantSQL)
+	assertParams(t, gotParams, wantParams)
+
+	// paging without TOP clause
+	g = Union(g1, g3).With(WithDialect(TSQL), WithLimit(5))
+	gotSQL, gotParams = g.Select()
+	wantSQL = "SELECT [id], [name] FROM [user] WHERE [age]>@p1 UNION SELECT [id], [name] FROM [guest] WHERE [banned]=@p2 " +
+		"ORDER BY (SELECT NULL) OFFSET 0 ROWS FETCH NEXT 5 ROWS ONLY"
+	wantParams = []interface{}{18, true}
+	assertSQL(t, gotSQL, wantSQL)
+	assertParams(t, gotParams, wantParams)
+
+	// derived table and subquery
+	ids := UnionAll(NewGenerator("user", WithColumns("id")), NewGenerator("guest", WithColumns("id"), WithAnd("banned", EQ(false))))
+	g = NewDerivedGenerator(Union(g1, g3), nil, "t", WithAnd("id", InQuery(ids)))
+	gotSQL, gotParams = g.Select("name")
+	wantSQL = "SELECT `name` FROM (SELECT `id`, `name` FROM `user` WHERE `age`>? UNION " +
+		"SELECT `id`, `name` FROM `guest` WHERE `banned`=?) AS `t` " +
+		"WHERE `id` IN (SELECT `id` FROM `user` UNION ALL SELECT `id` FROM `guest` WHERE `banned`=?)"
+	wantParams = []interface{}{18, true, false}
+	assertSQL(t, gotSQL, wantSQL)
+	assertParams(t, gotParams, wantParams)
+
+	// can only generate select statement
+	gotSQL, gotParams = Union(g1, g3).Delete()
+	assertSQL(t, gotSQL, "")
+	assertParams(t, gotParams, nil)
+}
@@ -0,0 +1,300 @@
+package sqlg
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/wwwangxc/sqlg/internal"
+)
+
+// getRecords return the columns and the records of insert statement from the
+// structure, pointer or slice of them
+//
+// The readonly columns and the expression columns are skipped, the autoincr
+// and omitempty columns are skipped when they are zero value in all the
+// records, ErrInconsistentRecords is returned when they are zero value in
+// some of the records only.
+func getRecords(target interface{}) ([]string, [][]interface{}, error) {
+	rows, err := structRows(target)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fields := internal.GetStructInfo(rows[0].Type()).Fields
+	values := make([][]interface{}, len(rows))
+	included := make([]bool, len(fields))
+	skipped := make([]bool, len(fields))
+	for i, row := range rows {
+		values[i] = make([]interface{}, len(fields))
+		for j, f := range fields {
+			if f.ReadOnly || f.Expr != "" {
+				continue
+			}
+
+			v, _ := internal.FieldByIndex(row, f.Index)
+			value, empty, err := fieldValue(v)
+			if err != nil {
+				return nil, nil, fmt.Errorf("sqlg: value of column %s: %w", f.Column, err)
+			}
+
+			values[i][j] = value
+			included[j] = included[j] || !empty || !(f.AutoIncr || f.OmitEmpty)
+			skipped[j] = skipped[j] || empty && (f.AutoIncr || f.OmitEmpty)
+			if included[j] && skipped[j] {
+				return nil, nil, fmt.Errorf("%w: column %s is zero value in some of the records", ErrInconsistentRecords, f.Column)
+			}
+		}
+	}
+
+	columns := make([]string, 0, len(fields))
+	for j, f := range fields {
+		if included[j] {
+			columns = append(columns, f.Column)
+		}
+	}
+
+	records := make([][]interface{}, 0, len(rows))
+	for _, v := range values {
+		record := make([]interface{}, 0, len(columns))
+		for j := range fields {
+			if included[j] {
+				record = append(record, v[j])
+			}
+		}
+
+		records = append(records, record)
+	}
+
+	return columns, records, nil
+}
+
+// structRows return the structures of target, which must be structure,
+// pointer or slice of them with same type
+func structRows(target interface{}) ([]reflect.Value, error) {
+	value := reflect.Indirect(reflect.ValueOf(target))
+	switch value.Kind() {
+	case reflect.Struct:
+		return []reflect.Value{addressable(value)}, nil
+	case reflect.Slice, reflect.Array:
+	default:
+		return nil, fmt.Errorf("%w: %T", ErrUnsupportedTarget, target)
+	}
+
+	if value.Len() == 0 {
+		return nil, ErrEmptyRecords
+	}
+
+	rows := make([]reflect.Value, 0, value.Len())
+	for i := 0; i < value.Len(); i++ {
+		row := value.Index(i)
+		for row.Kind() == reflect.Ptr || row.Kind() == reflect.Interface {
+			row = row.Elem()
+		}
+
+		if row.Kind() != reflect.Struct || (i > 0 && row.Type() != rows[0].Type()) {
+			return nil, fmt.Errorf("%w: element %d of %T", ErrUnsupportedTarget, i, target)
+		}
+
+		rows = append(rows, addressable(row))
+	}
+
+	return rows, nil
+}
+
+// addressable return the addressable copy of value, so that the methods of
+// pointer receiver can be called
+func addressable(v reflect.Value) reflect.Value {
+	if v.CanAddr() {
+		return v
+	}
+
+	copied := reflect.New(v.Type()).Elem()
+	copied.Set(v)
+	return copied
+}
+
+// fieldValue return the value of field and whether it's empty, the value of
+// driver.Valuer is converted, and it's empty when the value is nil
+func fieldValue(v reflect.Value) (interface{}, bool, error) {
+	if !v.IsValid() || (v.Kind() == reflect.Ptr && v.IsNil()) {
+		return nil, true, nil
+	}
+
+	valuer, ok := v.Interface().(driver.Valuer)
+	if !ok && v.CanAddr() {
+		valuer, ok = v.Addr().Interface().(driver.Valuer)
+	}
+
+	if !ok {
+		return v.Interface(), v.IsZero(), nil
+	}
+
+	value, err := valuer.Value()
+	return value, value == nil, err
+}
+
+// StructOption of the assignments generated from the structure
+type StructOption func(*structOptions)
+
+type structOptions struct {
+	only map[string]bool
+	omit map[string]bool
+}
+
+// OnlyColumns only assign the columns
+func OnlyColumns(columns ...string) StructOption {
+	return func(o *structOptions) {
+		if o.only == nil {
+			o.only = map[string]bool{}
+		}
+
+		for _, v := range columns {
+			o.only[v] = true
+		}
+	}
+}
+
+// OmitColumns skip the columns
+func OmitColumns(columns ...string) StructOption {
+	return func(o *structOptions) {
+		if o.omit == nil {
+			o.omit = map[string]bool{}
+		}
+
+		for _, v := range columns {
+			o.omit[v] = true
+		}
+	}
+}
+
+func newStructOptions(opts ...StructOption) *structOptions {
+	o := &structOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return o
+}
+
+// assignable return true when the field can be assigned by update statement,
+// the autoincr, readonly and expression columns are skipped
+func (o *structOptions) assignable(f internal.Field) bool {
+	switch {
+	case f.AutoIncr, f.ReadOnly, f.Expr != "":
+		return false
+	case o.only != nil && !o.only[f.Column]:
+		return false
+	default:
+		return !o.omit[f.Column]
+	}
+}
+
+// getAssignments return the assignments of update statement from the
+// structure or pointer, the omitempty columns are skipped when they are zero
+// value
+func getAssignments(target interface{}, opts ...StructOption) (*AssExpr, error) {
+	row, err := structValue(target)
+	if err != nil {
+		return nil, err
+	}
+
+	o := newStructOptions(opts...)
+	assExpr := NewAssExpr()
+	for _, f := range internal.GetStructInfo(row.Type()).Fields {
+		if !o.assignable(f) {
+			continue
+		}
+
+		// the fields of nil embedded pointer are not assigned
+		v, ok := internal.FieldByIndex(row, f.Index)
+		if !ok {
+			continue
+		}
+
+		value, empty, err := fieldValue(v)
+		if err != nil {
+			return nil, fmt.Errorf("sqlg: value of column %s: %w", f.Column, err)
+		}
+
+		if empty && f.OmitEmpty {
+			continue
+		}
+
+		assExpr.Put(f.Column, value)
+	}
+
+	return assExpr, nil
+}
+
+// getDiffAssignments return the assignments of the columns whose values are
+// changed, the old and new must be the same type of structure or pointer
+func getDiffAssignments(oldTarget, newTarget interface{}, opts ...StructOption) (*AssExpr, error) {
+	oldRow, err := structValue(oldTarget)
+	if err != nil {
+		return nil, err
+	}
+
+	newRow, err := structValue(newTarget)
+	if err != nil {
+		return nil, err
+	}
+
+	if oldRow.Type() != newRow.Type() {
+		return nil, fmt.Errorf("%w: %T is different from %T", ErrUnsupportedTarget, newTarget, oldTarget)
+	}
+
+	o := newStructOptions(opts...)
+	assExpr := NewAssExpr()
+	for _, f := range internal.GetStructInfo(newRow.Type()).Fields {
+		if !o.assignable(f) {
+			continue
+		}
+
+		v, _ := internal.FieldByIndex(oldRow, f.Index)
+		oldValue, _, err := fieldValue(v)
+		if err != nil {
+			return nil, fmt.Errorf("sqlg: value of column %s: %w", f.Column, err)
+		}
+
+		v, _ = internal.FieldByIndex(newRow, f.Index)
+		newValue, _, err := fieldValue(v)
+		if err != nil {
+			return nil, fmt.Errorf("sqlg: value of column %s: %w", f.Column, err)
+		}
+
+		if !equalValue(oldValue, newValue) {
+			assExpr.Put(f.Column, newValue)
+		}
+	}
+
+	return assExpr, nil
+}
+
+// equalValue return true when the values are deeply equal, the time is
+// compared by the instant, regardless of the monotonic clock reading and the
+// location
+func equalValue(x, y interface{}) bool {
+	vx, vy := reflect.Indirect(reflect.ValueOf(x)), reflect.Indirect(reflect.ValueOf(y))
+	if vx.IsValid() && vy.IsValid() {
+		tx, okx := vx.Interface().(time.Time)
+		ty, oky := vy.Interface().(time.Time)
+		if okx && oky {
+			return tx.Equal(ty)
+		}
+	}
+
+	return reflect.DeepEqual(x, y)
+}
+
+// structValue return the structure of target, which must be structure or
+// pointer
+func structValue(target interface{}) (reflect.Value, error) {
+	value := reflect.Indirect(reflect.ValueOf(target))
+	if value.Kind() != reflect.Struct {
+		return reflect.Value{}, fmt.Errorf("%w: %T", ErrUnsupportedTarget, target)
+	}
+
+	return addressable(value), nil
+}
@@ -0,0 +1,513 @@
+package sqlg
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/wwwangxc/sqlg/internal"
+)
+
+// status implement driver.Valuer with pointer receiver
+type status uint8
+
+func (s *status) Value() (driver.Value, error) {
+	if *s == 0 {
+		return nil, nil
+	}
+
+	return []string{"", "active", "banned"}[*s], nil
+}
+
+// failValuer return error
+type failValuer struct{}
+
+func (failValuer) Value() (driver.Value, error) {
+	return nil, errors.New("fail")
+}
+
+func TestGenerator_InsertByStruct(t *testing.T) {
+	type user struct {
+		ID        uint64         `db:"id,autoincr"`
+		Name      string         `db:"name"`
+		Age       uint8          `db:"age,omitempty"`
+		Nickname  sql.NullString `db:"nickname,omitempty"`
+		Status    status         `db:"status"`
+		CreatedAt time.Time      `db:"created_at,readonly"`
+		Total     int            `db:"total" expr:"COUNT(*)"`
+		Height    uint8          `db:"-"`
+		Weight    uint8
+		secret    string `db:"secret"`
+	}
+
+	g := NewGenerator("user")
+
+	// the options of tag are ignored by select, the unexported field is skipped
+	gotSQL, _, err := g.SelectByStruct(user{})
+	assertError(t, err, nil)
+	assertSQL(t, gotSQL, "SELECT `id`, `name`, `age`, `nickname`, `status`, `created_at`, COUNT(*) AS `total` FROM `user`")
+
+	// skipped columns
+	gotSQL, gotParams, err := g.InsertByStruct(user{Name: "tom", secret: "secret"})
+	assertError(t, err, nil)
+	assertSQL(t, gotSQL, "INSERT INTO `user` (`name`, `status`) VALUES (?,?)")
+	assertParams(t, gotParams, []interface{}{"tom", nil})
+
+	// pointer with driver.Valuer
+	u := &user{ID: 1, Name: "tom", Age: 5, Nickname: sql.NullString{String: "cat", Valid: true}, Status: 1}
+	gotSQL, gotParams, err = g.InsertByStruct(u)
+	assertError(t, err, nil)
+	assertSQL(t, gotSQL, "INSERT INTO `user` (`id`, `name`, `age`, `nickname`, `status`) VALUES (?,?,?,?,?)")
+	assertParams(t, gotParams, []interface{}{uint64(1), "tom", uint8(5), "cat", "active"})
+
+	// slice, the omitempty column is kept when it's not empty in all records
+	gotSQL, gotParams, err = g.InsertByStruct([]*user{{Name: "tom", Age: 5}, {Name: "jerry", Age: 3, Status: 2}})
+	assertError(t, err, nil)
+	assertSQL(t, gotSQL, "INSERT INTO `user` (`name`, `age`, `status`) VALUES (?,?,?), (?,?,?)")
+	assertParams(t, gotParams, []interface{}{"tom", uint8(5), nil, "jerry", uint8(3), "banned"})
+
+	// the autoincr or omitempty column is empty in some of the records
+	for _, records := range [][]user{{{Name: "tom"}, {Name: "jerry", Age: 3}}, {{ID: 1, Name: "tom"}, {Name: "jerry"}}} {
+		gotSQL, gotParams, err = g.InsertByStruct(records)
+		if !errors.Is(err, ErrInconsistentRecords) {
+			t.Errorf("got error dose not meet the expected\nexpected: %v\n  actual: %v", ErrInconsistentRecords, err)
+		}
+
+		assertSQL(t, gotSQL, "")
+		assertParams(t, gotParams, nil)
+	}
+
+	// dialect
+	gotSQL, gotParams, err = NewGenerator("user", WithDialect(Postgres)).InsertByStruct([]user{{Name: "tom"}, {Name: "jerry"}})
+	assertError(t, err, nil)
+	assertSQL(t, gotSQL, `INSERT INTO "user" ("name", "status") VALUES ($1,$2), ($3,$4)`)
+	assertParams(t, gotParams, []interface{}{"tom", nil, "jerry", nil})
+
+	// errors
+	_, _, err = g.InsertByStruct(nil)
+	if !errors.Is(err, ErrUnsupportedTarget) {
+		t.Errorf("got error dose not meet the expected\nexpected: %v\n  actual: %v", ErrUnsupportedTarget, err)
+	}
+
+	_, _, err = g.InsertByStruct([]interface{}{user{}, 1})
+	if !errors.Is(err, ErrUnsupportedTarget) {
+		t.Errorf("got error dose not meet the expected\nexpected: %v\n  actual: %v", ErrUnsupportedTarget, err)
+	}
+
+	_, _, err = g.InsertByStruct([]user{})
+	assertError(t, err, ErrEmptyRecords)
+
+	_, _, err = g.InsertByStruct(struct {
+		ID uint64 `db:"id,autoincr"`
+	}{})
+	assertError(t, err, ErrEmptyColumns)
+
+	_, _, err = g.InsertByStruct(struct {
+		Value failValuer `db:"value"`
+	}{})
+	assertError(t, err, errors.New("sqlg: value of column value: fail"))
+
+	// the statement is validated
+	_, _, err = NewGenerator("user", WithDialect(Postgres), InsertOrReplace()).InsertByStruct(user{Name: "tom"})
+	if !errors.Is(err, ErrEmptyConflictTarget) {
+		t.Errorf("got error dose not meet the expected\nexpected: %v\n  actual: %v", ErrEmptyConflictTarget, err)
+	}
+
+	_, _, err = NewGenerator("user; drop").InsertByStruct(user{Name: "tom"})
+	if !errors.Is(err, ErrUnsafeIdentifier) {
+		t.Errorf("got error dose not meet the expected\nexpected: %v\n  actual: %v", ErrUnsafeIdentifier, err)
+	}
+}
+
+func TestGenerator_UpdateByStruct(t *testing.T) {
+	type user struct {
+		ID        uint64         `db:"id,autoincr"`
+		Name      string         `db:"name"`
+		Age       uint8          `db:"age,omitempty"`
+		Nickname  sql.NullString `db:"nickname"`
+		Status    status         `db:"status"`
+		CreatedAt time.Time      `db:"created_at,readonly"`
+		Total     int            `db:"total" expr:"COUNT(*)"`
+	}
+
+	g := NewGenerator("user", WithAnd("id", EQ(1)))
+	gotSQL, gotParams, err := g.UpdateByStruct(user{ID: 1, Name: "tom", Status: 2})
+	assertError(t, err, nil)
+	assertSQL(t, gotSQL, "UPDATE `user` SET `name`=?, `nickname`=?, `status`=? WHERE `id`=?")
+	assertParams(t, gotParams, []interface{}{"tom", nil, "banned", 1})
+
+	gotSQL, gotParams, err = g.UpdateByStruct(&user{Name: "tom", Age: 5}, OmitColumns("nickname", "status"))
+	assertError(t, err, nil)
+	assertSQL(t, gotSQL, "UPDATE `user` SET `name`=?, `age`=? WHERE `id`=?")
+	assertParams(t, gotParams, []interface{}{"tom", uint8(5), 1})
+
+	gotSQL, gotParams, err = g.With(WithDialect(Postgres)).UpdateByStruct(&user{Name: "tom"}, OnlyColumns("name", "age"))
+	assertError(t, err, nil)
+	assertSQL(t, gotSQL, `UPDATE "user" SET "name"=$1 WHERE "id"=$2`)
+	assertParams(t, gotParams, []interface{}{"tom", 1})
+
+	// errors
+	_, _, err = g.UpdateByStruct(user{}, OnlyColumns("id", "age"))
+	assertError(t, err, ErrEmptyAssignment)
+
+	_, _, err = g.UpdateByStruct([]user{})
+	if !errors.Is(err, ErrUnsupportedTarget) {
+		t.Errorf("got error dose not meet the expected\nexpected: %v\n  actual: %v", ErrUnsupportedTarget, err)
+	}
+
+	// the statement is validated
+	_, _, err = g.With(WithDialect(Postgres), WithLimit(1)).UpdateByStruct(user{Name: "tom"})
+	if !errors.Is(err, ErrUnsupportedClause) {
+		t.Errorf("got error dose not meet the expected\nexpected: %v\n  actual: %v", ErrUnsupportedClause, err)
+	}
+
+	_, _, err = NewGenerator("user; drop").UpdateByStruct(user{Name: "tom"})
+	if !errors.Is(err, ErrUnsafeIdentifier) {
+		t.Errorf("got error dose not meet the expected\nexpected: %v\n  actual: %v", ErrUnsafeIdentifier, err)
+	}
+}
+
+func TestGenerator_UpdateDiff(t *testing.T) {
+	type user struct {
+		ID        uint64         `db:"id,autoincr"`
+		Name      string         `db:"name"`
+		Age       uint8          `db:"age,omitempty"`
+		Nickname  sql.NullString `db:"nickname"`
+		Tags      []byte         `db:"tags"`
+		UpdatedAt time.Time      `db:"updated_at,readonly"`
+	}
+
+	old := user{ID: 1, Name: "tom", Age: 5, Nickname: sql.NullString{String: "cat", Valid: true}, Tags: []byte("a")}
+	changed := old
+	changed.ID = 2
+	changed.Age = 0
+	changed.Nickname = sql.NullString{}
+	changed.Tags = []byte("a")
+	changed.UpdatedAt = time.Now()
+
+	g := NewGenerator("user", WithAnd("id", EQ(1)))
+	gotSQL, gotParams, err := g.UpdateDiff(old, &changed)
+	assertError(t, err, nil)
+	assertSQL(t, gotSQL, "UPDATE `user` SET `age`=?, `nickname`=? WHERE `id`=?")
+	assertParams(t, gotParams, []interface{}{uint8(0), nil, 1})
+
+	gotSQL, gotParams, err = g.UpdateDiff(&old, changed, OmitColumns("age"))
+	assertError(t, err, nil)
+	assertSQL(t, gotSQL, "UPDATE `user` SET `nickname`=? WHERE `id`=?")
+	assertParams(t, gotParams, []interface{}{nil, 1})
+
+	// nothing changed
+	_, _, err = g.UpdateDiff(old, old)
+	assertError(t, err, ErrEmptyAssignment)
+
+	// the time of same instant is not changed
+	type event struct {
+		StartAt  time.Time    `db:"start_at"`
+		EndAt    *time.Time   `db:"end_at"`
+		RemindAt sql.NullTime `db:"remind_at"`
+	}
+
+	now := time.Now()
+	end := now.Add(time.Hour)
+	endUTC := end.UTC().Round(0)
+	oldEvent := event{StartAt: now, EndAt: &end, RemindAt: sql.NullTime{Time: now, Valid: true}}
+	newEvent := event{StartAt: now.Round(0).In(time.FixedZone("UTC+8", 8*3600)), EndAt: &endUTC, RemindAt: sql.NullTime{Time: now.UTC(), Valid: true}}
+	_, _, err = g.UpdateDiff(oldEvent, newEvent)
+	assertError(t, err, ErrEmptyAssignment)
+
+	newEvent.StartAt = now.Add(time.Second)
+	newEvent.EndAt = nil
+	gotSQL, gotParams, err = g.UpdateDiff(oldEvent, newEvent)
+	assertError(t, err, nil)
+	assertSQL(t, gotSQL, "UPDATE `user` SET `start_at`=?, `end_at`=? WHERE `id`=?")
+	assertParams(t, gotParams, []interface{}{newEvent.StartAt, nil, 1})
+
+	// different type
+	_, _, err = g.UpdateDiff(old, struct{}{})
+	if !errors.Is(err, ErrUnsupportedTarget) {
+		t.Errorf("got error dose not meet the expected\nexpected: %v\n  actual: %v", ErrUnsupportedTarget, err)
+	}
+
+	// the statement is validated
+	_, _, err = g.With(WithDialect(TSQL), WithOrderBy("id")).UpdateDiff(old, changed)
+	if !errors.Is(err, ErrUnsupportedClause) {
+		t.Errorf("got error dose not meet the expected\nexpected: %v\n  actual: %v", ErrUnsupportedClause, err)
+	}
+}
+
+type baseModel struct {
+	ID        uint64    `db:"id,autoincr"`
+	CreatedAt time.Time `db:"created_at,readonly"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+type address struct {
+	City   string `db:"city"`
+	Street string `db:"street"`
+	Geo    struct {
+		Lat float64 `db:"lat"`
+		Lng float64 `db:"lng"`
+	} `db:"geo_,prefix"`
+}
+
+// node reference itself by embedded pointer
+type node struct {
+	*node
+	Name string `db:"name"`
+}
+
+func TestStructFields(t *testing.T) {
+	type shop struct {
+		baseModel
+		Name      string    `db:"name"`
+		UpdatedAt time.Time `db:"updated_at,omitempty"`
+		Address   address   `db:"addr_,prefix"`
+		Owner     *address  `db:"owner_,prefix"`
+		Extra     address   `db:"-"`
+		Ignored   address
+	}
+
+	tests := []struct {
+		name    string
+		target  interface{}
+		want    []string
+		wantErr error
+	}{
+		{
+			name:   "embedded and nested",
+			target: shop{},
+			want: []string{"id", "created_at", "name", "updated_at", "addr_city", "addr_street", "addr_geo_lat", "addr_geo_lng",
+				"owner_city", "owner_street", "owner_geo_lat", "owner_geo_lng"},
+		},
+		{
+			name:   "slice of pointer",
+			target: []*baseModel{},
+			want:   []string{"id", "created_at", "updated_at"},
+		},
+		{
+			name:   "recursive embedded pointer",
+			target: &node{},
+			want:   []string{"name"},
+		},
+		{
+			name:    "not structure",
+			target:  1,
+			wantErr: ErrUnsupportedTarget,
+		},
+		{
+			name:    "slice of not structure",
+			target:  []string{},
+			wantErr: ErrUnsupportedTarget,
+		},
+		{
+			name:    "map",
+			target:  map[string]interface{}{},
+			wantErr: ErrUnsupportedTarget,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info, err := getTargetInfo(tt.target)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("getTargetInfo() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			var got []interface{}
+			if info != nil {
+				got = info.Columns
+			}
+
+			assertParams(t, got, toInterfaces(tt.want))
+		})
+	}
+}
+
+func toInterfaces(strs []string) []interface{} {
+	if strs == nil {
+		return nil
+	}
+
+	result := make([]interface{}, 0, len(strs))
+	for _, v := range strs {
+		result = append(result, v)
+	}
+
+	return result
+}
+
+func TestGenerator_StructEmbedded(t *testing.T) {
+	type user struct {
+		*baseModel
+		Name    string  `db:"name"`
+		Address address `db:"addr_,prefix"`
+	}
+
+	u := user{Name: "tom", Address: address{City: "shanghai"}}
+	u.Address.Geo.Lat = 31.23
+
+	// the fields of nil embedded pointer are inserted as NULL
+	g := NewGenerator("user")
+	gotSQL, gotParams, err := g.InsertByStruct(u)
+	assertError(t, err, nil)
+	assertSQL(t, gotSQL, "INSERT INTO `user` (`updated_at`, `name`, `addr_city`, `addr_street`, `addr_geo_lat`, `addr_geo_lng`) "+
+		"VALUES (?,?,?,?,?,?)")
+	assertParams(t, gotParams, []interface{}{nil, "tom", "shanghai", "", 31.23, float64(0)})
+
+	// the fields of nil embedded pointer are not assigned
+	g = NewGenerator("user", WithAnd("id", EQ(1)))
+	gotSQL, gotParams, err = g.UpdateByStruct(u, OnlyColumns("updated_at", "name", "addr_city"))
+	assertError(t, err, nil)
+	assertSQL(t, gotSQL, "UPDATE `user` SET `name`=?, `addr_city`=? WHERE `id`=?")
+	assertParams(t, gotParams, []interface{}{"tom", "shanghai", 1})
+
+	updatedAt := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	changed := u
+	changed.baseModel = &baseModel{ID: 1, UpdatedAt: updatedAt}
+	changed.Address.Geo.Lng = 121.47
+	gotSQL, gotParams, err = g.UpdateDiff(u, changed)
+	assertError(t, err, nil)
+	assertSQL(t, gotSQL, "UPDATE `user` SET `updated_at`=?, `addr_geo_lng`=? WHERE `id`=?")
+	assertParams(t, gotParams, []interface{}{updatedAt, 121.47, 1})
+}
+
+func TestGetStructInfo(t *testing.T) {
+	type order struct {
+		baseModel
+		No     string `db:"no,pk"`
+		Amount int    `db:"amount"`
+	}
+
+	// cached by type
+	var wg sync.WaitGroup
+	infos := make([]*internal.StructInfo, 8)
+	for i := range infos {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			infos[i] = internal.GetStructInfo(reflect.TypeOf(order{}))
+		}(i)
+	}
+	wg.Wait()
+
+	for _, v := range infos {
+		if v != infos[0] {
+			t.Fatalf("internal.GetStructInfo() got different metadata of same type")
+		}
+	}
+
+	if allocs := testing.AllocsPerRun(100, func() { internal.GetStructInfo(reflect.TypeOf(order{})) }); allocs != 0 {
+		t.Errorf("internal.GetStructInfo() allocs = %v after warm-up, want 0", allocs)
+	}
+
+	// primary key
+	tests := []struct {
+		name   string
+		target interface{}
+		want   []string
+	}{
+		{
+			name:   "pk",
+			target: order{},
+			want:   []string{"no"},
+		},
+		{
+			name:   "autoincr",
+			target: baseModel{},
+			want:   []string{"id"},
+		},
+		{
+			name:   "none",
+			target: address{},
+			want:   nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, v := range internal.GetStructInfo(reflect.TypeOf(tt.target)).PrimaryKey {
+				got = append(got, v.Column)
+			}
+
+			assertParams(t, toInterfaces(got), toInterfaces(tt.want))
+		})
+	}
+
+	// select columns cached by dialect
+	info := internal.GetStructInfo(reflect.TypeOf(order{}))
+	if got, want := info.SelectColumns(Postgres), info.SelectColumns(Postgres); &got[0] != &want[0] {
+		t.Errorf("SelectColumns() got different columns of same dialect")
+	}
+
+	gotSQL, _, err := NewGenerator("order", WithDialect(Postgres)).SelectByStruct(order{})
+	assertError(t, err, nil)
+	assertSQL(t, gotSQL, `SELECT "id", "created_at", "updated_at", "no", "amount" FROM "order"`)
+
+	gotSQL, _, err = NewGenerator("order").SelectByStruct(&order{})
+	assertError(t, err, nil)
+	assertSQL(t, gotSQL, "SELECT `id`, `created_at`, `updated_at`, `no`, `amount` FROM `order`")
+}
+
+type benchmarkUser struct {
+	baseModel
+	Name     string         `db:"name"`
+	Age      uint8          `db:"age,omitempty"`
+	Nickname sql.NullString `db:"nickname"`
+	Address  address        `db:"addr_,prefix"`
+	Total    int            `db:"total" expr:"COUNT(*)"`
+}
+
+func BenchmarkStructFields(b *testing.B) {
+	b.ReportAllocs()
+	t := reflect.TypeOf(benchmarkUser{})
+	for i := 0; i < b.N; i++ {
+		internal.StructFields(t)
+	}
+}
+
+func BenchmarkGetStructInfo(b *testing.B) {
+	b.ReportAllocs()
+	t := reflect.TypeOf(benchmarkUser{})
+	internal.GetStructInfo(t)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		internal.GetStructInfo(t)
+	}
+}
+
+func BenchmarkGenerator_SelectByStruct(b *testing.B) {
+	b.ReportAllocs()
+	g := NewGenerator("user", WithAnd("id", EQ(1)))
+	for i := 0; i < b.N; i++ {
+		if _, _, err := g.SelectByStruct(benchmarkUser{}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGenerator_InsertByStruct(b *testing.B) {
+	b.ReportAllocs()
+	g := NewGenerator("user")
+	users := []benchmarkUser{{Name: "tom", Age: 5}, {Name: "jerry", Age: 3}}
+	for i := 0; i < b.N; i++ {
+		if _, _, err := g.InsertByStruct(users); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGenerator_UpdateByStruct(b *testing.B) {
+	b.ReportAllocs()
+	g := NewGenerator("user", WithAnd("id", EQ(1)))
+	u := benchmarkUser{Name: "tom"}
+	for i := 0; i < b.N; i++ {
+		if _, _, err := g.UpdateByStruct(u); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
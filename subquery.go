@@ -0,0 +1,44 @@
+package sqlg
+
+import (
+	"github.com/wwwangxc/sqlg/internal"
+)
+
+var (
+	_ internal.Query     = (*subquery)(nil)
+	_ internal.Validator = (*subquery)(nil)
+)
+
+// subquery embed the select statement of generator into another statement
+type subquery struct {
+	g       *Generator
+	columns []interface{}
+}
+
+func newSubquery(g *Generator, columns []interface{}) *subquery {
+	return &subquery{
+		g:       g,
+		columns: columns,
+	}
+}
+
+// ToSQL return select statement of subquery
+//
+// The subquery is generated with the dialect of the outer statement, and the
+// placeholders are left to be rebound by the outer statement.
+func (s *subquery) ToSQL(d internal.Dialect) (string, []interface{}) {
+	if s == nil || s.g == nil {
+		return "", nil
+	}
+
+	return s.g.withDialect(d).genSelect(s.columns...)
+}
+
+// Validate the select statement of subquery
+func (s *subquery) Validate(d internal.Dialect) error {
+	if s == nil {
+		return nil
+	}
+
+	return s.g.withDialect(d).validateSelect(s.columns)
+}
@@ -0,0 +1,67 @@
+package sqlg
+
+import "testing"
+
+func TestSubquery(t *testing.T) {
+	on := NewCompExpr()
+	on.Put("o.user_id", EQColumn("u.id"))
+
+	paid := NewGenerator("order",
+		WithAlias("o"),
+		WithJoin("user", "u", on),
+		WithAnd("o.status", EQ("paid")),
+		WithGroupBy("o.user_id"),
+		WithLimit(100))
+
+	banned := NewGenerator("ban", WithAnd("ban.user_id", EQColumn("user.id")), WithAnd("ban.reason", EQ("spam")))
+	maxAge := NewGenerator("user", WithAnd("vip", EQ(true)))
+
+	g := NewGenerator("user",
+		WithAnd("status", EQ("active")),
+		WithAnd("id", InQuery(paid, "o.user_id")),
+		WithAnd("", NExistsQuery(banned)),
+		WithOr("age", EQQuery(maxAge, Raw("MAX(age)"))),
+		WithAnd("level", GT(3)))
+	gotSQL, gotParams := g.Select("id")
+
+	wantSQL := "SELECT `id` FROM `user` WHERE `status`=? " +
+		"AND `id` IN (SELECT `o`.`user_id` FROM `order` AS `o` INNER JOIN `user` AS `u` ON `o`.`user_id`=`u`.`id` " +
+		"WHERE `o`.`status`=? GROUP BY `o`.`user_id` LIMIT 100) " +
+		"AND NOT EXISTS (SELECT * FROM `ban` WHERE `ban`.`user_id`=`user`.`id` AND `ban`.`reason`=?) " +
+		"OR `age`=(SELECT MAX(age) FROM `user` WHERE `vip`=?) " +
+		"AND `level`>?"
+	wantParams := []interface{}{"active", "paid", "spam", true, 3}
+	assertSQL(t, gotSQL, wantSQL)
+	assertParams(t, gotParams, wantParams)
+
+	// generated with the dialect of outer statement
+	g = NewGenerator("user",
+		WithDialect(Postgres),
+		WithAnd("status", EQ("active")),
+		WithAnd("id", NInQuery(paid, "o.user_id")),
+		WithAnd("", ExistsQuery(banned)),
+		WithAnd("level", GT(3)))
+	gotSQL, gotParams = g.Select("id")
+
+	wantSQL = `SELECT "id" FROM "user" WHERE "status"=$1 ` +
+		`AND "id" NOT IN (SELECT "o"."user_id" FROM "order" AS "o" INNER JOIN "user" AS "u" ON "o"."user_id"="u"."id" ` +
+		`WHERE "o"."status"=$2 GROUP BY "o"."user_id" LIMIT 100) ` +
+		`AND EXISTS (SELECT * FROM "ban" WHERE "ban"."user_id"="user"."id" AND "ban"."reason"=$3) ` +
+		`AND "level">$4`
+	wantParams = []interface{}{"active", "paid", "spam", 3}
+	assertSQL(t, gotSQL, wantSQL)
+	assertParams(t, gotParams, wantParams)
+
+	// update with subquery
+	assExpr := NewAssExpr()
+	assExpr.Put("vip", true)
+	g = NewGenerator("user", WithDialect(Postgres), WithAnd("id", InQuery(paid, "o.user_id")))
+	gotSQL, gotParams = g.Update(assExpr)
+
+	wantSQL = `UPDATE "user" SET "vip"=$1 ` +
+		`WHERE "id" IN (SELECT "o"."user_id" FROM "order" AS "o" INNER JOIN "user" AS "u" ON "o"."user_id"="u"."id" ` +
+		`WHERE "o"."status"=$2 GROUP BY "o"."user_id" LIMIT 100)`
+	wantParams = []interface{}{true, "paid"}
+	assertSQL(t, gotSQL, wantSQL)
+	assertParams(t, gotParams, wantParams)
+}
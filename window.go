@@ -0,0 +1,338 @@
+package sqlg
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/wwwangxc/sqlg/internal"
+)
+
+// FrameBound bound of the window frame clause, which is created by Preceding,
+// Following and the predefined bounds, the zero value means omitted
+type FrameBound struct {
+	bound string
+}
+
+// Frame bounds of the window frame clause
+var (
+	UnboundedPreceding = FrameBound{bound: "UNBOUNDED PRECEDING"}
+	UnboundedFollowing = FrameBound{bound: "UNBOUNDED FOLLOWING"}
+	CurrentRow         = FrameBound{bound: "CURRENT ROW"}
+)
+
+// Preceding frame bound
+//
+// EXP:
+//
+//	${n} PRECEDING
+func Preceding(n uint32) FrameBound {
+	return FrameBound{bound: fmt.Sprintf("%d PRECEDING", n)}
+}
+
+// Following frame bound
+//
+// EXP:
+//
+//	${n} FOLLOWING
+func Following(n uint32) FrameBound {
+	return FrameBound{bound: fmt.Sprintf("%d FOLLOWING", n)}
+}
+
+// String return the sql of frame bound
+func (b FrameBound) String() string {
+	return b.bound
+}
+
+// Window specification of the window function and the named window
+type Window struct {
+	partitionBy []interface{}
+	orderBy     []orderBy
+	frame       string
+}
+
+// NewWindow create window specification
+func NewWindow() *Window {
+	return &Window{}
+}
+
+// PartitionBy append partition by columns
+//
+// EXP:
+//
+//	PARTITION BY ${column1}, ${column2}
+func (w *Window) PartitionBy(columns ...interface{}) *Window {
+	if w == nil {
+		return nil
+	}
+
+	w.partitionBy = append(w.partitionBy, columns...)
+	return w
+}
+
+// OrderBy append order by column
+//
+// EXP:
+//
+//	ORDER BY ${column} ASC
+func (w *Window) OrderBy(column interface{}) *Window {
+	if w == nil {
+		return nil
+	}
+
+	w.orderBy = append(w.orderBy, orderBy{column: column})
+	return w
+}
+
+// OrderByDESC append order by column
+//
+// EXP:
+//
+//	ORDER BY ${column} DESC
+func (w *Window) OrderByDESC(column interface{}) *Window {
+	if w == nil {
+		return nil
+	}
+
+	w.orderBy = append(w.orderBy, orderBy{column: column, desc: true})
+	return w
+}
+
+// Rows set the frame clause in ROWS unit, the end bound is omitted when it's
+// zero FrameBound
+//
+// EXP:
+//
+//	ROWS BETWEEN ${start} AND ${end}
+//	ROWS ${start}
+func (w *Window) Rows(start, end FrameBound) *Window {
+	return w.withFrame("ROWS", start, end)
+}
+
+// Range set the frame clause in RANGE unit, the end bound is omitted when
+// it's zero FrameBound
+//
+// EXP:
+//
+//	RANGE BETWEEN ${start} AND ${end}
+//	RANGE ${start}
+func (w *Window) Range(start, end FrameBound) *Window {
+	return w.withFrame("RANGE", start, end)
+}
+
+func (w *Window) withFrame(unit string, start, end FrameBound) *Window {
+	if w == nil {
+		return nil
+	}
+
+	switch {
+	case start.bound == "":
+		w.frame = ""
+	case end.bound == "":
+		w.frame = fmt.Sprintf("%s %s", unit, start.bound)
+	default:
+		w.frame = fmt.Sprintf("%s BETWEEN %s AND %s", unit, start.bound, end.bound)
+	}
+
+	return w
+}
+
+// String return the window specification with backtick quoted names, the
+// args of trusted expressions are dropped
+func (w *Window) String() string {
+	sql, _ := w.toSQL(strictDialect{MySQL})
+	return sql
+}
+
+func (w *Window) toSQL(d internal.Dialect) (string, []interface{}) {
+	if w == nil {
+		return "", nil
+	}
+
+	var args []interface{}
+	items := make([]string, 0, 3)
+	if len(w.partitionBy) > 0 {
+		columns, a := internal.SafeNamesWithArgs(d, w.partitionBy)
+		items = append(items, fmt.Sprintf("PARTITION BY %s", strings.Join(columns, ", ")))
+		args = append(args, a...)
+	}
+
+	if len(w.orderBy) > 0 {
+		orders := make([]string, 0, len(w.orderBy))
+		for _, v := range w.orderBy {
+			direction := "ASC"
+			if v.desc {
+				direction = "DESC"
+			}
+
+			column, a := internal.SafeNameWithArgs(d, v.column)
+			orders = append(orders, fmt.Sprintf("%s %s", column, direction))
+			args = append(args, a...)
+		}
+
+		items = append(items, fmt.Sprintf("ORDER BY %s", strings.Join(orders, ", ")))
+	}
+
+	if w.frame != "" {
+		items = append(items, w.frame)
+	}
+
+	return strings.Join(items, " "), args
+}
+
+// names return the columns of window specification
+func (w *Window) names() []interface{} {
+	if w == nil {
+		return nil
+	}
+
+	names := make([]interface{}, 0, len(w.partitionBy)+len(w.orderBy))
+	names = append(names, w.partitionBy...)
+	for _, v := range w.orderBy {
+		names = append(names, v.column)
+	}
+
+	return names
+}
+
+var _ internal.Trusted = (*WindowFunc)(nil)
+
+// WindowFunc window function expression, which is trusted expression and can
+// be used as column of select statement directly or by As
+type WindowFunc struct {
+	function RawExpr
+	window   *Window
+	name     string
+}
+
+// NewWindowFunc create window function from the function expression, such as
+// the aggregate expression
+//
+// EXP:
+//
+//	NewWindowFunc(Sum("amount")).Over(NewWindow().PartitionBy("user_id"))
+func NewWindowFunc(function RawExpr) *WindowFunc {
+	return &WindowFunc{function: function}
+}
+
+// RowNumber window function
+//
+// EXP:
+//
+//	ROW_NUMBER() OVER (${window})
+func RowNumber() *WindowFunc {
+	return NewWindowFunc(Func("ROW_NUMBER"))
+}
+
+// Rank window function
+//
+// EXP:
+//
+//	RANK() OVER (${window})
+func Rank() *WindowFunc {
+	return NewWindowFunc(Func("RANK"))
+}
+
+// DenseRank window function
+//
+// EXP:
+//
+//	DENSE_RANK() OVER (${window})
+func DenseRank() *WindowFunc {
+	return NewWindowFunc(Func("DENSE_RANK"))
+}
+
+// Lag window function, the offset is omitted when it's 0
+//
+// EXP:
+//
+//	LAG(${column}, ${offset}) OVER (${window})
+func Lag(column string, offset uint32) *WindowFunc {
+	return NewWindowFunc(offsetFunction("LAG", column, offset))
+}
+
+// Lead window function, the offset is omitted when it's 0
+//
+// EXP:
+//
+//	LEAD(${column}, ${offset}) OVER (${window})
+func Lead(column string, offset uint32) *WindowFunc {
+	return NewWindowFunc(offsetFunction("LEAD", column, offset))
+}
+
+func offsetFunction(function, column string, offset uint32) RawExpr {
+	if offset == 0 {
+		return aggregate(function, column)
+	}
+
+	return Raw(fmt.Sprintf("%s(%s, %d)", function, quoteColumn(column), offset))
+}
+
+// Over set the window specification of the window function
+//
+// EXP:
+//
+//	${function} OVER (${window})
+func (f *WindowFunc) Over(w *Window) *WindowFunc {
+	if f == nil {
+		return nil
+	}
+
+	f.window = w
+	f.name = ""
+	return f
+}
+
+// OverWindow reference the named window defined by WithWindow
+//
+// EXP:
+//
+//	${function} OVER ${name}
+func (f *WindowFunc) OverWindow(name string) *WindowFunc {
+	if f == nil {
+		return nil
+	}
+
+	f.window = nil
+	f.name = name
+	return f
+}
+
+// As return the window function expression with alias
+//
+// EXP:
+//
+//	${function} OVER (${window}) AS ${alias}
+func (f *WindowFunc) As(alias string) RawExpr {
+	if alias == "" {
+		return Raw("?", f)
+	}
+
+	return Raw(fmt.Sprintf("? AS %s", quoteColumn(alias)), f)
+}
+
+// RawSQL return the window function expression and the args of ?
+// placeholders, the names are quoted with backtick and will be requoted with
+// the dialect of statement
+func (f *WindowFunc) RawSQL() (string, []interface{}) {
+	if f == nil {
+		return "", nil
+	}
+
+	function, args := f.function.RawSQL()
+	if f.name != "" {
+		return fmt.Sprintf("%s OVER %s", function, quoteColumn(f.name)), args
+	}
+
+	window, windowArgs := f.window.toSQL(strictDialect{MySQL})
+	params := make([]interface{}, 0, len(args)+len(windowArgs))
+	params = append(params, args...)
+	params = append(params, windowArgs...)
+	return fmt.Sprintf("%s OVER (%s)", function, window), params
+}
+
+// String return the window function expression with backtick quoted names,
+// the args of trusted expressions are dropped
+func (f *WindowFunc) String() string {
+	sql, _ := f.RawSQL()
+	return sql
+}
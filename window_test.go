@@ -0,0 +1,165 @@
+package sqlg
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestWindowFunc(t *testing.T) {
+	tests := []struct {
+		name string
+		fn   *WindowFunc
+		want string
+	}{
+		{
+			name: "row number",
+			fn:   RowNumber().Over(NewWindow().PartitionBy("user_id").OrderByDESC("created_at")),
+			want: "ROW_NUMBER() OVER (PARTITION BY `user_id` ORDER BY `created_at` DESC)",
+		},
+		{
+			name: "rank",
+			fn:   Rank().Over(NewWindow().OrderByDESC("score")),
+			want: "RANK() OVER (ORDER BY `score` DESC)",
+		},
+		{
+			name: "dense rank",
+			fn:   DenseRank().Over(NewWindow().PartitionBy("class", "grade").OrderBy("score")),
+			want: "DENSE_RANK() OVER (PARTITION BY `class`, `grade` ORDER BY `score` ASC)",
+		},
+		{
+			name: "lag",
+			fn:   Lag("amount", 1).Over(NewWindow().OrderBy("id")),
+			want: "LAG(`amount`, 1) OVER (ORDER BY `id` ASC)",
+		},
+		{
+			name: "lead without offset",
+			fn:   Lead("o.amount", 0).Over(NewWindow().OrderBy("o.id")),
+			want: "LEAD(`o`.`amount`) OVER (ORDER BY `o`.`id` ASC)",
+		},
+		{
+			name: "aggregate with rows frame",
+			fn:   NewWindowFunc(Sum("amount")).Over(NewWindow().PartitionBy("user_id").OrderBy("id").Rows(UnboundedPreceding, CurrentRow)),
+			want: "SUM(`amount`) OVER (PARTITION BY `user_id` ORDER BY `id` ASC ROWS BETWEEN UNBOUNDED PRECEDING AND CURRENT ROW)",
+		},
+		{
+			name: "aggregate with range frame",
+			fn:   NewWindowFunc(Avg("price")).Over(NewWindow().OrderBy("day").Range(Preceding(7), Following(7))),
+			want: "AVG(`price`) OVER (ORDER BY `day` ASC RANGE BETWEEN 7 PRECEDING AND 7 FOLLOWING)",
+		},
+		{
+			name: "frame without end",
+			fn:   NewWindowFunc(Count("*")).Over(NewWindow().OrderBy("id").Rows(Preceding(3), FrameBound{})),
+			want: "COUNT(*) OVER (ORDER BY `id` ASC ROWS 3 PRECEDING)",
+		},
+		{
+			name: "empty window",
+			fn:   NewWindowFunc(Sum("amount")).Over(nil),
+			want: "SUM(`amount`) OVER ()",
+		},
+		{
+			name: "named window",
+			fn:   RowNumber().OverWindow("w"),
+			want: "ROW_NUMBER() OVER `w`",
+		},
+		{
+			name: "nil",
+			fn:   nil,
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.fn.String(); got != tt.want {
+				t.Errorf("String() got = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWindow_String(t *testing.T) {
+	w := NewWindow().PartitionBy("user_id", Raw("DATE(`created_at`)")).OrderByDESC("id").Range(UnboundedPreceding, Following(1))
+	wantWindow := "PARTITION BY `user_id`, DATE(`created_at`) ORDER BY `id` DESC RANGE BETWEEN UNBOUNDED PRECEDING AND 1 FOLLOWING"
+	if got := w.String(); got != wantWindow {
+		t.Errorf("Window.String() got = %q, want %q", got, wantWindow)
+	}
+
+	// consistent with the window specification
+	wantFunc := "SUM(`amount`) OVER (" + wantWindow + ")"
+	if got := NewWindowFunc(Sum("amount")).Over(w).String(); got != wantFunc {
+		t.Errorf("WindowFunc.String() got = %q, want %q", got, wantFunc)
+	}
+
+	// the zero start bound clears the frame clause
+	w.Rows(FrameBound{}, CurrentRow)
+	wantWindow = "PARTITION BY `user_id`, DATE(`created_at`) ORDER BY `id` DESC"
+	if got := w.String(); got != wantWindow {
+		t.Errorf("Window.String() got = %q, want %q", got, wantWindow)
+	}
+}
+
+func TestGenerator_SelectWindow(t *testing.T) {
+	// latest order per user
+	w := NewWindow().PartitionBy("user_id").OrderByDESC("created_at")
+	sub := NewGenerator("order", WithColumns("id", "user_id", RowNumber().Over(w).As("rn")))
+	g := NewDerivedGenerator(sub, nil, "t", WithAnd("rn", EQ(1)))
+
+	gotSQL, gotParams := g.Select("id", "user_id")
+	wantSQL := "SELECT `id`, `user_id` FROM (SELECT `id`, `user_id`, " +
+		"ROW_NUMBER() OVER (PARTITION BY `user_id` ORDER BY `created_at` DESC) AS `rn` FROM `order`) AS `t` WHERE `rn`=?"
+	assertSQL(t, gotSQL, wantSQL)
+	assertParams(t, gotParams, []interface{}{1})
+
+	// named window
+	ops := []Option{
+		WithAnd("status", EQ("paid")),
+		WithWindow("w", NewWindow().PartitionBy("user_id").OrderBy("id").Rows(UnboundedPreceding, CurrentRow)),
+		WithOrderBy("id"),
+	}
+
+	g = NewGenerator("order", ops...)
+	gotSQL, gotParams = g.Select("id", NewWindowFunc(Sum("amount")).OverWindow("w").As("total"), Lag("amount", 1).OverWindow("w").As("prev"))
+	wantSQL = "SELECT `id`, SUM(`amount`) OVER `w` AS `total`, LAG(`amount`, 1) OVER `w` AS `prev` FROM `order` " +
+		"WHERE `status`=? WINDOW `w` AS (PARTITION BY `user_id` ORDER BY `id` ASC ROWS BETWEEN UNBOUNDED PRECEDING AND CURRENT ROW) " +
+		"ORDER BY `id` ASC"
+	assertSQL(t, gotSQL, wantSQL)
+	assertParams(t, gotParams, []interface{}{"paid"})
+
+	// requoted with the dialect
+	g = NewGenerator("order", append(ops, WithDialect(Postgres))...)
+	gotSQL, _ = g.Select("id", NewWindowFunc(Sum("amount")).OverWindow("w").As("total"))
+	wantSQL = `SELECT "id", SUM("amount") OVER "w" AS "total" FROM "order" ` +
+		`WHERE "status"=$1 WINDOW "w" AS (PARTITION BY "user_id" ORDER BY "id" ASC ROWS BETWEEN UNBOUNDED PRECEDING AND CURRENT ROW) ` +
+		`ORDER BY "id" ASC`
+	assertSQL(t, gotSQL, wantSQL)
+}
+
+func TestGenerator_SelectWindowArgs(t *testing.T) {
+	// the args of trusted expressions are kept in textual order
+	day := Raw("DATE_ADD(`created_at`, INTERVAL ? HOUR)", 8)
+	w := NewWindow().PartitionBy("user_id", day).OrderByDESC(Raw("FIELD(`status`, ?, ?)", "paid", "sent"))
+	g := NewGenerator("order", WithAnd("amount", GT(0)),
+		WithWindow("w", NewWindow().PartitionBy(day).OrderBy("id")),
+		WithOrderBy(Raw("ABS(`amount` - ?)", 100)))
+
+	gotSQL, gotParams := g.Select("id", RowNumber().Over(w).As("rn"), NewWindowFunc(Sum("amount")).OverWindow("w").As("total"))
+	wantSQL := "SELECT `id`, ROW_NUMBER() OVER (PARTITION BY `user_id`, DATE_ADD(`created_at`, INTERVAL ? HOUR) " +
+		"ORDER BY FIELD(`status`, ?, ?) DESC) AS `rn`, SUM(`amount`) OVER `w` AS `total` FROM `order` WHERE `amount`>? " +
+		"WINDOW `w` AS (PARTITION BY DATE_ADD(`created_at`, INTERVAL ? HOUR) ORDER BY `id` ASC) ORDER BY ABS(`amount` - ?) ASC"
+	assertSQL(t, gotSQL, wantSQL)
+	assertParams(t, gotParams, []interface{}{8, "paid", "sent", 0, 8, 100})
+
+	// requoted with the dialect
+	gotSQL, gotParams = g.With(WithDialect(Postgres)).Select(RowNumber().Over(w))
+	wantSQL = `SELECT ROW_NUMBER() OVER (PARTITION BY "user_id", DATE_ADD("created_at", INTERVAL $1 HOUR) ` +
+		`ORDER BY FIELD("status", $2, $3) DESC) FROM "order" WHERE "amount">$4 ` +
+		`WINDOW "w" AS (PARTITION BY DATE_ADD("created_at", INTERVAL $5 HOUR) ORDER BY "id" ASC) ORDER BY ABS("amount" - $6) ASC`
+	assertSQL(t, gotSQL, wantSQL)
+	assertParams(t, gotParams, []interface{}{8, "paid", "sent", 0, 8, 100})
+
+	// the placeholders mismatch the args
+	w = NewWindow().PartitionBy(Raw("DATE(?)"))
+	if _, _, err := NewGenerator("order", WithWindow("w", w)).BuildSelect(); !errors.Is(err, ErrPlaceholderMismatch) {
+		t.Errorf("got error dose not meet the expected\nexpected: %v\n  actual: %v", ErrPlaceholderMismatch, err)
+	}
+}